@@ -0,0 +1,35 @@
+// Package env resolves the deployment environment from APP_ENV. It has no dependencies
+// so both utils and logutil can use it without an import cycle.
+package env
+
+import (
+	"os"
+	"strings"
+)
+
+// Env is a deployment environment.
+type Env string
+
+const (
+	Unset       Env = ""
+	Development Env = "development"
+	Staging     Env = "staging"
+	Production  Env = "production"
+)
+
+// Current returns the environment named by APP_ENV. When APP_ENV is unset it returns Unset, and callers keep
+// the behavior they had before environment detection existed, so setting APP_ENV is an explicit opt-in.
+// Unrecognized values are treated as production so that verbose, development-only behavior is never
+// enabled by a typo.
+func Current() Env {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("APP_ENV"))) {
+	case "":
+		return Unset
+	case "dev", "development", "local":
+		return Development
+	case "stage", "staging":
+		return Staging
+	default:
+		return Production
+	}
+}
@@ -0,0 +1,53 @@
+package logutil
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Allow-list state; a nil allowList means every field is allowed
+var (
+	allowList   map[string]struct{}
+	allowListMu sync.RWMutex
+	droppedKeys sync.Map
+)
+
+// SetFieldAllowList restricts additional fields to the given keys; any other additional field is dropped
+// and reported once in a warning. The standard fields (event, correlationID, timestamp, status, error) are
+// always logged. Calling it without keys removes the restriction
+func SetFieldAllowList(keys ...string) {
+	allowListMu.Lock()
+	defer allowListMu.Unlock()
+
+	if len(keys) == 0 {
+		allowList = nil
+		return
+	}
+	allowList = make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		allowList[key] = struct{}{}
+	}
+}
+
+// fieldAllowed reports whether an additional field may be logged, warning once per dropped key
+func fieldAllowed(key string) bool {
+	allowListMu.RLock()
+	list := allowList
+	allowListMu.RUnlock()
+
+	if list == nil {
+		return true
+	}
+	if _, ok := list[key]; ok {
+		return true
+	}
+
+	if _, warned := droppedKeys.LoadOrStore(key, true); !warned {
+		logrus.WithFields(logrus.Fields{
+			"event":      "log_field_dropped",
+			"droppedKey": key,
+		}).Warn("Log field not in allow list was dropped")
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package logutil
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// ErrBatchWriterClosed is returned when writing to a closed BatchWriter
+var ErrBatchWriterClosed = errors.New("batch writer is closed")
+
+// BatchWriter buffers records and writes them to the underlying writer in one call, once maxBatch
+// records have accumulated and otherwise at least every maxWait, on a fixed interval that size-triggered
+// flushes do not reset. Each Write call is one record
+type BatchWriter struct {
+	out      io.Writer
+	maxBatch int
+
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	pending int
+	err     error
+	closed  bool
+
+	stop func()
+}
+
+// NewBatchWriter starts a BatchWriter over out; a non-positive maxWait defaults to one second. Close must be called to flush the remaining records and stop the background flusher
+func NewBatchWriter(out io.Writer, maxBatch int, maxWait time.Duration) *BatchWriter {
+	if maxBatch < 1 {
+		maxBatch = 1
+	}
+	if maxWait <= 0 {
+		maxWait = time.Second
+	}
+
+	bw := &BatchWriter{out: out, maxBatch: maxBatch}
+	bw.stop = startTicker(maxWait, func() {
+		bw.mu.Lock()
+		defer bw.mu.Unlock()
+
+		bw.flushLocked()
+	})
+	return bw
+}
+
+// Write buffers p as one record and flushes if the batch is full.
+// An error from an earlier background flush is returned once
+func (bw *BatchWriter) Write(p []byte) (int, error) {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	if bw.closed {
+		return 0, ErrBatchWriterClosed
+	}
+	if err := bw.err; err != nil {
+		bw.err = nil
+		return 0, err
+	}
+
+	bw.buf.Write(p)
+	bw.pending++
+	if bw.pending >= bw.maxBatch {
+		if err := bw.flushLocked(); err != nil {
+			bw.err = nil
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes the buffered records immediately
+func (bw *BatchWriter) Flush() error {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	return bw.flushLocked()
+}
+
+// Close stops the background flusher and flushes the remaining records
+func (bw *BatchWriter) Close() error {
+	bw.stop()
+
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	if bw.closed {
+		return nil
+	}
+	bw.closed = true
+
+	if err := bw.flushLocked(); err != nil {
+		return err
+	}
+	return bw.err
+}
+
+// flushLocked writes out the buffer; the caller must hold bw.mu
+func (bw *BatchWriter) flushLocked() error {
+	if bw.pending == 0 {
+		return nil
+	}
+
+	_, err := bw.out.Write(bw.buf.Bytes())
+	bw.buf.Reset()
+	bw.pending = 0
+	if err != nil {
+		bw.err = err
+	}
+	return err
+}
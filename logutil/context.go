@@ -0,0 +1,98 @@
+package logutil
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// contextKey is the type of every context key set by this package, so values cannot collide with other packages.
+// Keys are only reachable through the typed setters and getters below
+type contextKey int
+
+const (
+	correlationIDKey contextKey = iota
+	spanIDKey
+	fieldBagKey
+	entryKey
+	requestSummaryKey
+	errorDedupKey
+)
+
+// ContextWithCorrelationID returns a copy of ctx carrying the given correlation ID
+func ContextWithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey, correlationID)
+}
+
+// CorrelationIDFromContext returns the correlation ID stored in ctx, or an empty string if none is set
+func CorrelationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey).(string)
+	return correlationID
+}
+
+// fieldBag collects fields added while a request is processed
+type fieldBag struct {
+	mu     sync.Mutex
+	fields map[string]interface{}
+}
+
+// ContextWithFieldBag returns a copy of ctx carrying an empty bag for AddField.
+// The logging middleware installs one per request and merges it into the completion log
+func ContextWithFieldBag(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fieldBagKey, &fieldBag{fields: make(map[string]interface{})})
+}
+
+// AddField records a field in the request's field bag. It is safe for concurrent use and does nothing if ctx has no bag
+func AddField(ctx context.Context, key string, value interface{}) {
+	bag, ok := ctx.Value(fieldBagKey).(*fieldBag)
+	if !ok {
+		return
+	}
+
+	bag.mu.Lock()
+	bag.fields[key] = value
+	bag.mu.Unlock()
+}
+
+// FieldsFromContext returns a copy of the fields added to the request's field bag
+func FieldsFromContext(ctx context.Context) map[string]interface{} {
+	bag, ok := ctx.Value(fieldBagKey).(*fieldBag)
+	if !ok {
+		return nil
+	}
+
+	bag.mu.Lock()
+	defer bag.mu.Unlock()
+
+	fields := make(map[string]interface{}, len(bag.fields))
+	for k, v := range bag.fields {
+		fields[k] = v
+	}
+	return fields
+}
+
+// noopEntry is returned by EntryFromContext when ctx carries no entry
+var noopEntry = newNoopEntry()
+
+// newNoopEntry builds an entry whose logger discards everything
+func newNoopEntry() *logrus.Entry {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.PanicLevel)
+	return logrus.NewEntry(logger)
+}
+
+// ContextWithEntry returns a copy of ctx carrying entry, so deep call stacks can log with its fields
+func ContextWithEntry(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, entryKey, entry)
+}
+
+// EntryFromContext returns the entry stored in ctx, or an entry that discards everything when none is set
+func EntryFromContext(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(entryKey).(*logrus.Entry); ok && entry != nil {
+		return entry
+	}
+	return noopEntry
+}
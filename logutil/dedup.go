@@ -0,0 +1,106 @@
+package logutil
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// errorDedups maps correlation IDs of requests with error deduplication to their state.
+// activeErrorDedups lets LogError skip the lookup entirely while no request uses deduplication
+var (
+	errorDedups       sync.Map
+	activeErrorDedups atomic.Int64
+)
+
+// errorDedup remembers the errors already logged for one request
+type errorDedup struct {
+	correlationID string
+	mu            sync.Mutex
+	seen          map[string]*repeatedError
+	order         []string
+}
+
+// repeatedError is an error logged once for a request together with how often it occurred
+type repeatedError struct {
+	event       string
+	message     string
+	fingerprint string
+	count       int
+}
+
+// ContextWithErrorDedup makes LogError log each error only once per request: later errors under the
+// correlation ID stored in ctx with the same event and fingerprint (see ErrorFingerprint) are counted instead.
+// Call FlushErrorDedup with the returned context when the request ends to report the repeats
+func ContextWithErrorDedup(ctx context.Context) context.Context {
+	dedup := &errorDedup{
+		correlationID: CorrelationIDFromContext(ctx),
+		seen:          make(map[string]*repeatedError),
+	}
+	if dedup.correlationID != "" {
+		errorDedups.Store(dedup.correlationID, dedup)
+		activeErrorDedups.Add(1)
+	}
+	return context.WithValue(ctx, errorDedupKey, dedup)
+}
+
+// FlushErrorDedup stops deduplicating the request's errors and logs every error that was suppressed,
+// once, with its "repeat_count". It does nothing if ctx was not set up by ContextWithErrorDedup
+func FlushErrorDedup(ctx context.Context) {
+	dedup, ok := ctx.Value(errorDedupKey).(*errorDedup)
+	if !ok {
+		return
+	}
+	if dedup.correlationID != "" && errorDedups.CompareAndDelete(dedup.correlationID, dedup) {
+		activeErrorDedups.Add(-1)
+	}
+
+	dedup.mu.Lock()
+	defer dedup.mu.Unlock()
+
+	for _, key := range dedup.order {
+		repeated := dedup.seen[key]
+		if repeated.count < 2 {
+			continue
+		}
+
+		fields := logrus.Fields{
+			"event":         repeated.event,
+			"correlationID": dedup.correlationID,
+			"error":         repeated.message,
+			"fingerprint":   repeated.fingerprint,
+			"repeat_count":  repeated.count,
+			"status":        "error",
+		}
+		setTimestamp(fields)
+
+		logrus.WithFields(fields).Error("Error repeated")
+	}
+}
+
+// suppressRepeatedError records the error for its request and reports whether it was already logged there
+func suppressRepeatedError(correlationID, event, fingerprint string, err error) bool {
+	if activeErrorDedups.Load() == 0 || correlationID == "" {
+		return false
+	}
+	value, ok := errorDedups.Load(correlationID)
+	if !ok {
+		return false
+	}
+
+	dedup := value.(*errorDedup)
+	key := event + "\x00" + fingerprint
+
+	dedup.mu.Lock()
+	defer dedup.mu.Unlock()
+
+	if repeated, ok := dedup.seen[key]; ok {
+		repeated.count++
+		return true
+	}
+	dedup.seen[key] = &repeatedError{event: event, message: err.Error(), fingerprint: fingerprint, count: 1}
+	dedup.order = append(dedup.order, key)
+	return false
+}
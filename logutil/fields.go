@@ -0,0 +1,94 @@
+package logutil
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// SetString stores a string in Additional and returns fields for chaining
+func (fields *LogFields) SetString(key, value string) *LogFields {
+	return fields.set(key, value)
+}
+
+// SetInt stores an integer in Additional and returns fields for chaining
+func (fields *LogFields) SetInt(key string, value int64) *LogFields {
+	return fields.set(key, value)
+}
+
+// SetBool stores a boolean in Additional and returns fields for chaining
+func (fields *LogFields) SetBool(key string, value bool) *LogFields {
+	return fields.set(key, value)
+}
+
+// SetDuration stores a duration in Additional as its string form (e.g. "1.5s") and returns fields for chaining
+func (fields *LogFields) SetDuration(key string, value time.Duration) *LogFields {
+	return fields.set(key, value.String())
+}
+
+// set stores value under key, allocating Additional on first use
+func (fields *LogFields) set(key string, value interface{}) *LogFields {
+	if fields.Additional == nil {
+		fields.Additional = make(map[string]interface{})
+	}
+	fields.Additional[key] = value
+	return fields
+}
+
+// Validate reports the first Additional value that cannot be represented in JSON,
+// such as a channel, function or complex number, including inside nested maps and slices
+func (fields LogFields) Validate() error {
+	for key, value := range fields.Additional {
+		if err := validateFieldValue(reflect.ValueOf(value)); err != nil {
+			return fmt.Errorf("additional field %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// validateFieldValue walks v and rejects kinds that JSON cannot encode
+func validateFieldValue(v reflect.Value) error {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return fmt.Errorf("type %s is not allowed", v.Type())
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return validateFieldValue(v.Elem())
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if err := validateFieldValue(v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateFieldValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MergeFieldMaps merges maps into a new map where later maps win on key collisions.
+// Nil maps are skipped and the inputs are never modified
+func MergeFieldMaps(maps ...map[string]interface{}) map[string]interface{} {
+	size := 0
+	for _, m := range maps {
+		size += len(m)
+	}
+
+	merged := make(map[string]interface{}, size)
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
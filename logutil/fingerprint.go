@@ -0,0 +1,60 @@
+package logutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sync"
+)
+
+// FingerprintRule replaces every match of Pattern in an error message with Replacement before it is hashed
+type FingerprintRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// defaultFingerprintRules strip quoted strings, UUIDs, 0x-prefixed or long hex values and numbers, in that order
+var defaultFingerprintRules = []FingerprintRule{
+	{Pattern: regexp.MustCompile(`"[^"]*"|'[^']*'`), Replacement: "<str>"},
+	{Pattern: regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), Replacement: "<uuid>"},
+	{Pattern: regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b|\b[0-9a-f]{16,}\b`), Replacement: "<hex>"},
+	{Pattern: regexp.MustCompile(`\d+`), Replacement: "<num>"},
+}
+
+// fingerprintRules holds the normalization rules applied by ErrorFingerprint
+var (
+	fingerprintRules   = defaultFingerprintRules
+	fingerprintRulesMu sync.RWMutex
+)
+
+// SetFingerprintRules replaces the rules ErrorFingerprint uses to normalize error messages.
+// Rules are applied in order; calling it without rules restores the defaults (quoted strings, UUIDs, hex values, numbers)
+func SetFingerprintRules(rules ...FingerprintRule) {
+	if len(rules) == 0 {
+		rules = defaultFingerprintRules
+	}
+
+	fingerprintRulesMu.Lock()
+	fingerprintRules = append([]FingerprintRule(nil), rules...)
+	fingerprintRulesMu.Unlock()
+}
+
+// ErrorFingerprint returns a stable hash of err's message with its variable parts normalized, so
+// "user 123 not found" and "user 456 not found" share a fingerprint. Error logs carry it as the "fingerprint" field
+func ErrorFingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	fingerprintRulesMu.RLock()
+	rules := fingerprintRules
+	fingerprintRulesMu.RUnlock()
+
+	message := err.Error()
+	for _, rule := range rules {
+		message = rule.Pattern.ReplaceAllString(message, rule.Replacement)
+	}
+
+	sum := sha256.Sum256([]byte(message))
+	return hex.EncodeToString(sum[:8])
+}
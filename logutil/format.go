@@ -0,0 +1,152 @@
+package logutil
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ehsan-Eghbali/common/internal/env"
+	"github.com/sirupsen/logrus"
+)
+
+// logFormat selects the formatter installed on the standard logger
+type logFormat int
+
+const (
+	formatJSON logFormat = iota
+	formatLogfmt
+)
+
+// Formatter settings; every change rebuilds the formatter through applyFormatter
+var (
+	formatMu      sync.Mutex
+	currentFormat = formatJSON
+	messageKey    string
+	levelKey      string
+	timeKey       string
+	sortedKeys    bool
+	keyStyle      = AsIs
+)
+
+// UseJSONFormatter switches log output to JSON, the default
+func UseJSONFormatter() {
+	setFormat(formatJSON)
+}
+
+// UseLogfmtFormatter switches log output to logfmt (key=value pairs) without colors.
+// Values containing spaces or other special characters are quoted
+func UseLogfmtFormatter() {
+	setFormat(formatLogfmt)
+}
+
+// SetMessageKey renames the message field, "msg" by default, e.g. to "message"; an empty key restores the default
+func SetMessageKey(key string) {
+	setFieldKey(&messageKey, key)
+}
+
+// SetLevelKey renames the level field, "level" by default; an empty key restores the default
+func SetLevelKey(key string) {
+	setFieldKey(&levelKey, key)
+}
+
+// SetTimeKey renames the formatter's time field, "time" by default; an empty key restores the default
+func SetTimeKey(key string) {
+	setFieldKey(&timeKey, key)
+}
+
+// SetSortedKeys sorts the built-in time, level and msg keys together with the fields in logfmt output. Off by default.
+// Field order is deterministic either way: logfmt always sorts fields after the built-in keys, and JSON output is
+// always fully sorted since encoding/json orders map keys
+func SetSortedKeys(sorted bool) {
+	formatMu.Lock()
+	defer formatMu.Unlock()
+
+	sortedKeys = sorted
+	applyFormatter()
+}
+
+// setFieldKey stores a key remapping and reinstalls the formatter
+func setFieldKey(target *string, key string) {
+	formatMu.Lock()
+	defer formatMu.Unlock()
+
+	*target = key
+	applyFormatter()
+}
+
+// setFormat stores the format and reinstalls the formatter
+func setFormat(format logFormat) {
+	formatMu.Lock()
+	defer formatMu.Unlock()
+
+	currentFormat = format
+	applyFormatter()
+}
+
+// formatFromEnv returns the format requested by the LOG_FORMAT environment variable.
+// Without it, development (APP_ENV) logs as text and every other environment as JSON
+func formatFromEnv() logFormat {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("LOG_FORMAT"))) {
+	case "logfmt", "text":
+		return formatLogfmt
+	case "json":
+		return formatJSON
+	}
+
+	if env.Current() == env.Development {
+		return formatLogfmt
+	}
+	return formatJSON
+}
+
+// applyFormatter builds the formatter for the current settings and installs it; the caller must hold formatMu
+func applyFormatter() {
+	formatter := newBaseFormatter()
+	if keyStyle != AsIs {
+		formatter = &keyStyleFormatter{inner: formatter, style: keyStyle}
+	}
+	if streamSplitEnabled.Load() {
+		formatter = &streamLevelFormatter{inner: formatter}
+	}
+	logrus.SetFormatter(formatter)
+}
+
+// newBaseFormatter builds the JSON or logfmt formatter for the current settings; the caller must hold formatMu
+func newBaseFormatter() logrus.Formatter {
+	switch currentFormat {
+	case formatLogfmt:
+		formatter := &logrus.TextFormatter{
+			DisableColors:    true,
+			FullTimestamp:    true,
+			TimestampFormat:  time.RFC3339,
+			QuoteEmptyFields: true,
+			FieldMap:         buildFieldMap(),
+		}
+		if sortedKeys {
+			formatter.SortingFunc = sort.Strings
+		}
+		return formatter
+	default:
+		return &logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339,
+			FieldMap:        buildFieldMap(),
+		}
+	}
+}
+
+// buildFieldMap builds the logrus key remapping from the configured keys; the caller must hold formatMu
+func buildFieldMap() logrus.FieldMap {
+	fieldMap := logrus.FieldMap{}
+	if messageKey != "" {
+		fieldMap[logrus.FieldKeyMsg] = messageKey
+	}
+	if levelKey != "" {
+		fieldMap[logrus.FieldKeyLevel] = levelKey
+	}
+	if timeKey != "" {
+		fieldMap[logrus.FieldKeyTime] = timeKey
+	}
+	return fieldMap
+}
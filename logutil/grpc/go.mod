@@ -0,0 +1,18 @@
+module github.com/Ehsan-Eghbali/common/logutil/grpc
+
+go 1.23.3
+
+require (
+	github.com/Ehsan-Eghbali/common v0.0.0-20261016011034-e08bdb1c5a7d
+	google.golang.org/grpc v1.67.1
+)
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	golang.org/x/net v0.28.0 // indirect
+	golang.org/x/sys v0.24.0 // indirect
+	golang.org/x/text v0.17.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240814211410-ddb44dafa142 // indirect
+	google.golang.org/protobuf v1.34.2 // indirect
+)
@@ -0,0 +1,58 @@
+// Package grpc wires logutil correlation IDs into gRPC servers.
+// It lives in its own module so the gRPC dependency stays out of the core packages.
+//
+// The module requires a published version of github.com/Ehsan-Eghbali/common; the go.work file next to
+// go.mod builds it against the local tree instead while developing in this repository. To release it,
+// tag the root module (vX.Y.Z), bump the require in go.mod and the replace in go.work to that tag, then
+// tag this module with its path prefix (logutil/grpc/vX.Y.Z)
+package grpc
+
+import (
+	"context"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// CorrelationIDKey is the incoming metadata key that carries the correlation ID
+const CorrelationIDKey = "x-correlation-id"
+
+// CorrelationIDFromGRPC reads the correlation ID from incoming gRPC metadata, or returns an empty string if none is set
+func CorrelationIDFromGRPC(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(CorrelationIDKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// UnaryServerInterceptor stores the incoming correlation ID in the request context and logs the start and end of each call.
+// A new correlation ID is generated when the caller did not send one
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		correlationID := CorrelationIDFromGRPC(ctx)
+		if correlationID == "" {
+			correlationID = logutil.GenerateCorrelationID()
+		}
+		ctx = logutil.ContextWithCorrelationID(ctx, correlationID)
+
+		fields := map[string]interface{}{
+			"method": info.FullMethod,
+		}
+		logutil.LogRelationalStart(correlationID, "grpc_request", fields)
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			logutil.LogError(correlationID, "grpc_request", err, fields)
+			return resp, err
+		}
+
+		logutil.LogRelationalEnd(correlationID, "grpc_request", fields)
+		return resp, nil
+	}
+}
@@ -0,0 +1,69 @@
+package logutil
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultHeartbeatInterval is used by StartHeartbeat when no positive interval is given
+const DefaultHeartbeatInterval = 30 * time.Second
+
+// StartHeartbeat logs event at info level every interval, with fields computed fresh on each tick (fields may be nil).
+// A non-positive interval uses DefaultHeartbeatInterval.
+// The returned stop function ends the ticker goroutine and waits for it to exit; it is safe to call more than once
+func StartHeartbeat(event string, interval time.Duration, fields func() map[string]interface{}) (stop func()) {
+	if interval <= 0 {
+		interval = DefaultHeartbeatInterval
+	}
+
+	return startTicker(interval, func() {
+		if !logrus.IsLevelEnabled(logrus.InfoLevel) {
+			return
+		}
+
+		var additionalFields map[string]interface{}
+		if fields != nil {
+			additionalFields = fields()
+		}
+
+		entryFields := logrus.Fields{
+			"event":  event,
+			"status": "alive",
+		}
+		setTimestamp(entryFields)
+		mergeFields(entryFields, additionalFields)
+
+		logrus.WithFields(entryFields).Info("Heartbeat")
+	})
+}
+
+// startTicker calls tick every interval on its own goroutine until the returned stop function is called
+func startTicker(interval time.Duration, tick func()) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				tick()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-exited
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package logutil
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FieldKeyStyle selects how field keys are rewritten when an entry is emitted
+type FieldKeyStyle int
+
+const (
+	// AsIs keeps keys exactly as they were logged
+	AsIs FieldKeyStyle = iota
+	// SnakeCase rewrites keys like "correlationID" to "correlation_id"
+	SnakeCase
+	// CamelCase rewrites keys like "parent_span_id" to "parentSpanId"
+	CamelCase
+)
+
+// SetFieldKeyStyle rewrites the keys of every field, built-in and additional alike, at emission time
+func SetFieldKeyStyle(style FieldKeyStyle) {
+	formatMu.Lock()
+	defer formatMu.Unlock()
+
+	keyStyle = style
+	applyFormatter()
+}
+
+// keyStyleFormatter rewrites field keys after all hooks ran and then delegates to the wrapped formatter
+type keyStyleFormatter struct {
+	inner logrus.Formatter
+	style FieldKeyStyle
+}
+
+// Format formats a copy of entry whose field keys are rewritten to the configured style
+func (f *keyStyleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data := make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		data[convertKey(k, f.style)] = v
+	}
+
+	styled := *entry
+	styled.Data = data
+	return f.inner.Format(&styled)
+}
+
+// convertKey rewrites key to style
+func convertKey(key string, style FieldKeyStyle) string {
+	switch style {
+	case SnakeCase:
+		return toSnakeCase(key)
+	case CamelCase:
+		return toCamelCase(key)
+	default:
+		return key
+	}
+}
+
+// toSnakeCase splits key at case changes and separators and joins the lower-cased words with underscores
+func toSnakeCase(key string) string {
+	runes := []rune(key)
+	var b strings.Builder
+	for i, r := range runes {
+		if r == '-' || r == ' ' {
+			r = '_'
+		}
+		if unicode.IsUpper(r) && i > 0 && runes[i-1] != '_' {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// toCamelCase joins the words of key separated by '_' or '-', capitalizing every word but the first
+func toCamelCase(key string) string {
+	parts := strings.FieldsFunc(key, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	if len(parts) == 0 {
+		return key
+	}
+
+	var b strings.Builder
+	b.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
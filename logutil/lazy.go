@@ -0,0 +1,37 @@
+package logutil
+
+import (
+	"fmt"
+)
+
+// Lazy is a field value computed only when the entry is actually emitted.
+// Use it for expensive values so suppressed logs (debug mode off, level disabled) cost nothing;
+// every log function checks its level before merging fields, so a filtered call never evaluates it:
+//
+//	logutil.LogDebug(id, "sync", map[string]interface{}{"payload": logutil.Lazy(func() interface{} { return dump(obj) })})
+//
+// A plain func() interface{} value is treated the same way
+type Lazy func() interface{}
+
+// resolveLazy evaluates v if it is a lazy value, turning a panic during evaluation into a placeholder
+func resolveLazy(v interface{}) (out interface{}) {
+	var fn func() interface{}
+	switch lazy := v.(type) {
+	case Lazy:
+		fn = lazy
+	case func() interface{}:
+		fn = lazy
+	default:
+		return v
+	}
+	if fn == nil {
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			out = fmt.Sprintf("<lazy field panicked: %v>", r)
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,147 @@
+package logutil
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Logger is an instance-based alternative to the package-level functions.
+// Each Logger carries its own base fields and its own LogOnce cache, while output,
+// level and formatter come from the underlying *logrus.Logger
+type Logger struct {
+	logger *logrus.Logger
+
+	mu           sync.Mutex
+	fields       logrus.Fields
+	loggedEvents map[string]bool
+}
+
+// NewLogger creates a Logger writing through base, or through the standard logrus logger when base is nil
+func NewLogger(base *logrus.Logger) *Logger {
+	if base == nil {
+		base = logrus.StandardLogger()
+	}
+	return &Logger{
+		logger:       base,
+		fields:       logrus.Fields{},
+		loggedEvents: make(map[string]bool),
+	}
+}
+
+// Clone returns a new Logger derived from l.
+// The underlying *logrus.Logger (output, level, formatter) is shared, so configuring it affects both loggers.
+// Base fields are copied, so fields added to the clone do not leak into l, and the clone starts with an empty LogOnce cache
+func (l *Logger) Clone() *Logger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fields := make(logrus.Fields, len(l.fields))
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	return &Logger{
+		logger:       l.logger,
+		fields:       fields,
+		loggedEvents: make(map[string]bool),
+	}
+}
+
+// AddBaseFields adds fields that are included in every entry logged through l
+func (l *Logger) AddBaseFields(additionalFields map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	mergeFields(l.fields, additionalFields)
+}
+
+// LogRelationalStart logs the start of an event if debug mode is enabled
+func (l *Logger) LogRelationalStart(correlationID, event string, additionalFields map[string]interface{}) *logrus.Entry {
+	if !debugMode || !l.logger.IsLevelEnabled(logrus.InfoLevel) {
+		return nil
+	}
+
+	entry := l.entry(logrus.Fields{
+		"event":         event,
+		"correlationID": correlationID,
+		"status":        "started",
+	}, additionalFields)
+	entry.Info("Event started")
+	return entry
+}
+
+// LogRelationalEnd logs the end of an event if debug mode is enabled
+func (l *Logger) LogRelationalEnd(correlationID, event string, additionalFields map[string]interface{}) *logrus.Entry {
+	if !debugMode || !l.logger.IsLevelEnabled(logrus.InfoLevel) {
+		return nil
+	}
+
+	entry := l.entry(logrus.Fields{
+		"event":         event,
+		"correlationID": correlationID,
+		"status":        "completed",
+	}, additionalFields)
+	entry.Info("Event completed")
+	return entry
+}
+
+// LogError logs an error event regardless of debug mode
+func (l *Logger) LogError(correlationID, event string, err error, additionalFields map[string]interface{}) {
+	if !l.logger.IsLevelEnabled(logrus.ErrorLevel) {
+		return
+	}
+
+	fingerprint := ErrorFingerprint(err)
+	if suppressRepeatedError(correlationID, event, fingerprint, err) {
+		return
+	}
+
+	l.entry(logrus.Fields{
+		"event":         event,
+		"correlationID": correlationID,
+		"error":         err.Error(),
+		"fingerprint":   fingerprint,
+		"status":        "error",
+	}, additionalFields).Error("Error occurred")
+}
+
+// LogOnce logs an event only once per Logger to prevent duplicate logs
+func (l *Logger) LogOnce(event string, err error, additionalFields map[string]interface{}) {
+	if !l.logger.IsLevelEnabled(logrus.InfoLevel) {
+		return
+	}
+
+	l.mu.Lock()
+	if l.loggedEvents[event] {
+		l.mu.Unlock()
+		return
+	}
+	l.loggedEvents[event] = true
+	l.mu.Unlock()
+
+	fields := logrus.Fields{
+		"event": event,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	l.entry(fields, additionalFields).Info("Event logged once")
+}
+
+// entry builds an entry from the base fields, the event fields and the call-site fields, in increasing precedence
+func (l *Logger) entry(eventFields logrus.Fields, additionalFields map[string]interface{}) *logrus.Entry {
+	l.mu.Lock()
+	fields := make(logrus.Fields, len(l.fields)+len(eventFields)+len(additionalFields)+1)
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	l.mu.Unlock()
+
+	for k, v := range eventFields {
+		fields[k] = v
+	}
+	setTimestamp(fields)
+	mergeFields(fields, additionalFields)
+
+	return l.logger.WithFields(fields)
+}
@@ -0,0 +1,106 @@
+// Package logtest captures entries logged through logutil so tests can assert on them.
+package logtest
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+	"github.com/sirupsen/logrus"
+)
+
+// CaptureHook records every entry logged through the standard logrus logger
+type CaptureHook struct {
+	mu      sync.Mutex
+	Entries []logutil.LogFields
+}
+
+// Capture installs a CaptureHook on the standard logger, lowers its level to trace so debug entries
+// are recorded too, and discards the regular output. The returned function restores the previous
+// hooks, level and output
+func Capture() (*CaptureHook, func()) {
+	logger := logrus.StandardLogger()
+	hook := &CaptureHook{}
+
+	hooks := make(logrus.LevelHooks)
+	for level, levelHooks := range logger.Hooks {
+		hooks[level] = append(hooks[level], levelHooks...)
+	}
+	hooks.Add(hook)
+
+	previousHooks := logger.ReplaceHooks(hooks)
+	previousLevel := logger.GetLevel()
+	previousOutput := logger.Out
+	logger.SetLevel(logrus.TraceLevel)
+	logger.SetOutput(io.Discard)
+
+	return hook, func() {
+		logger.ReplaceHooks(previousHooks)
+		logger.SetLevel(previousLevel)
+		logger.SetOutput(previousOutput)
+	}
+}
+
+// Levels makes the hook fire for every log level
+func (h *CaptureHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire decodes the entry into LogFields; fields other than the standard ones go to Additional,
+// along with the entry's "level" and "msg"
+func (h *CaptureHook) Fire(entry *logrus.Entry) error {
+	fields := logutil.LogFields{
+		Additional: map[string]interface{}{
+			"level": entry.Level.String(),
+			"msg":   entry.Message,
+		},
+	}
+
+	for k, v := range entry.Data {
+		switch k {
+		case "event":
+			fields.Event = fmt.Sprint(v)
+		case "correlationID":
+			fields.CorrelationID = fmt.Sprint(v)
+		case "timestamp":
+			fields.Timestamp = fmt.Sprint(v)
+		case "status":
+			fields.Status = fmt.Sprint(v)
+		case "error":
+			fields.Error = fmt.Sprint(v)
+		default:
+			fields.Additional[k] = v
+		}
+	}
+
+	h.mu.Lock()
+	h.Entries = append(h.Entries, fields)
+	h.mu.Unlock()
+	return nil
+}
+
+// All returns a copy of the captured entries, safe to use while logging continues
+func (h *CaptureHook) All() []logutil.LogFields {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]logutil.LogFields(nil), h.Entries...)
+}
+
+// Has reports whether an entry with the given event and status was captured
+func (h *CaptureHook) Has(event, status string) bool {
+	for _, entry := range h.All() {
+		if entry.Event == event && entry.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset discards the captured entries
+func (h *CaptureHook) Reset() {
+	h.mu.Lock()
+	h.Entries = nil
+	h.mu.Unlock()
+}
@@ -1,18 +1,25 @@
 package logutil
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 // Global variables for debug mode and logging cache
 var (
-	debugMode    bool                    // Determines if debug logs should be displayed
-	loggedEvents = make(map[string]bool) // Cache to store logged events to prevent duplicates
-	mutex        sync.Mutex
+	debugMode       bool                    // Determines if debug logs should be displayed
+	injectTimestamp = true                  // Determines if a manual "timestamp" field is added to entries
+	loggedEvents    = make(map[string]bool) // Cache to store logged events to prevent duplicates
+	mutex           sync.Mutex
 	// Mutex to synchronize access to loggedEvents
 )
 
@@ -26,12 +33,11 @@ type LogFields struct {
 	Additional    map[string]interface{} `json:"additional,omitempty"`
 }
 
-// Init initializes the logrus logger with JSON formatting and INFO level
+// Init initializes the logrus logger with INFO level, logging as text in development and as JSON elsewhere.
+// LOG_FORMAT=logfmt or LOG_FORMAT=json overrides the environment default
 func Init() {
-	logrus.SetFormatter(&logrus.JSONFormatter{
-		TimestampFormat: time.RFC3339,
-	})
-	logrus.SetOutput(os.Stdout)
+	setFormat(formatFromEnv())
+	SetOutput(os.Stdout)
 	logrus.SetLevel(logrus.InfoLevel)
 }
 
@@ -40,23 +46,46 @@ func SetDebugMode(debug bool) {
 	debugMode = debug
 }
 
+// SetInjectTimestamp enables or disables the manual "timestamp" field on all log entries.
+// Disable it when the formatter or log pipeline already stamps entries
+func SetInjectTimestamp(inject bool) {
+	injectTimestamp = inject
+}
+
 // GenerateCorrelationID generates a unique ID for tracking logs and events
 func GenerateCorrelationID() string {
 	return uuid.New().String()
 }
 
+// NewPhase derives a phase correlation ID "parentID/phaseName" and logs a phase-start marker.
+// Logs of the phase can be queried by the exact phase ID or, together with the other phases, by the parent prefix
+func NewPhase(parentID, phaseName string) string {
+	phaseID := parentID + "/" + phaseName
+
+	fields := logrus.Fields{
+		"event":         phaseName,
+		"correlationID": phaseID,
+		"parentID":      parentID,
+		"status":        "phase_started",
+	}
+	setTimestamp(fields)
+
+	logrus.WithFields(fields).Info("Phase started")
+	return phaseID
+}
+
 // LogRelationalStart logs the start of an event if debug mode is enabled using map[string]interface{}
 func LogRelationalStart(correlationID, event string, additionalFields map[string]interface{}) *logrus.Entry {
-	if !debugMode {
+	if !debugMode || !logrus.IsLevelEnabled(logrus.InfoLevel) {
 		return nil
 	}
 
 	fields := logrus.Fields{
 		"event":         event,
 		"correlationID": correlationID,
-		"timestamp":     time.Now().UTC().Format(time.RFC3339),
 		"status":        "started",
 	}
+	setTimestamp(fields)
 	mergeFields(fields, additionalFields)
 
 	entry := logrus.WithFields(fields)
@@ -66,16 +95,16 @@ func LogRelationalStart(correlationID, event string, additionalFields map[string
 
 // LogRelationalEnd logs the end of an event if debug mode is enabled using map[string]interface{}
 func LogRelationalEnd(correlationID, event string, additionalFields map[string]interface{}) *logrus.Entry {
-	if !debugMode {
+	if !debugMode || !logrus.IsLevelEnabled(logrus.InfoLevel) {
 		return nil
 	}
 
 	fields := logrus.Fields{
 		"event":         event,
 		"correlationID": correlationID,
-		"timestamp":     time.Now().UTC().Format(time.RFC3339),
 		"status":        "completed",
 	}
+	setTimestamp(fields)
 	mergeFields(fields, additionalFields)
 
 	entry := logrus.WithFields(fields)
@@ -83,22 +112,144 @@ func LogRelationalEnd(correlationID, event string, additionalFields map[string]i
 	return entry
 }
 
+// LogDebug logs an event at debug level if debug mode is enabled using map[string]interface{}
+func LogDebug(correlationID, event string, additionalFields map[string]interface{}) {
+	if !debugMode || !logrus.IsLevelEnabled(logrus.DebugLevel) {
+		return
+	}
+
+	fields := logrus.Fields{
+		"event":         event,
+		"correlationID": correlationID,
+		"status":        "debug",
+	}
+	setTimestamp(fields)
+	mergeFields(fields, additionalFields)
+
+	logrus.WithFields(fields).Debug("Debug event")
+}
+
 // LogError logs an error event regardless of debug mode using map[string]interface{}
 func LogError(correlationID, event string, err error, additionalFields map[string]interface{}) {
+	if !logrus.IsLevelEnabled(logrus.ErrorLevel) {
+		return
+	}
+
+	fingerprint := ErrorFingerprint(err)
+	if suppressRepeatedError(correlationID, event, fingerprint, err) {
+		return
+	}
+
 	fields := logrus.Fields{
 		"event":         event,
 		"correlationID": correlationID,
-		"timestamp":     time.Now().UTC().Format(time.RFC3339),
 		"error":         err.Error(),
+		"fingerprint":   fingerprint,
 		"status":        "error",
 	}
+	setTimestamp(fields)
 	mergeFields(fields, additionalFields)
 
 	logrus.WithFields(fields).Error("Error occurred")
 }
 
+// LogAndReturn logs err through LogError and returns it, so it can be used as `return logutil.LogAndReturn(...)`.
+// It returns nil without logging when err is nil
+func LogAndReturn(correlationID, event string, err error, additionalFields map[string]interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	LogError(correlationID, event, err, additionalFields)
+	return err
+}
+
+// LogAndReturnf wraps err as "<formatted message>: err", logs the wrapped error and returns it.
+// It returns nil without logging when err is nil
+func LogAndReturnf(correlationID, event string, err error, additionalFields map[string]interface{}, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	wrapped := fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err)
+	LogError(correlationID, event, wrapped, additionalFields)
+	return wrapped
+}
+
+// LogErrors logs several errors as a single error event, skipping nil errors.
+// Nothing is logged when every error is nil
+func LogErrors(correlationID, event string, errs []error, additionalFields map[string]interface{}) {
+	if !logrus.IsLevelEnabled(logrus.ErrorLevel) {
+		return
+	}
+
+	var nonNil []error
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		nonNil = append(nonNil, err)
+		messages = append(messages, err.Error())
+	}
+	if len(nonNil) == 0 {
+		return
+	}
+
+	fields := logrus.Fields{
+		"event":         event,
+		"correlationID": correlationID,
+		"error":         errors.Join(nonNil...).Error(),
+		"errors":        messages,
+		"count":         len(nonNil),
+		"status":        "error",
+	}
+	setTimestamp(fields)
+	mergeFields(fields, additionalFields)
+
+	logrus.WithFields(fields).Error("Errors occurred")
+}
+
+// LogInfo logs an informational event regardless of debug mode using map[string]interface{}
+func LogInfo(correlationID, event string, additionalFields map[string]interface{}) {
+	if !logrus.IsLevelEnabled(logrus.InfoLevel) {
+		return
+	}
+
+	fields := logrus.Fields{
+		"event":         event,
+		"correlationID": correlationID,
+		"status":        "info",
+	}
+	setTimestamp(fields)
+	mergeFields(fields, additionalFields)
+
+	logrus.WithFields(fields).Info("Event logged")
+}
+
+// LogWarning logs a warning event regardless of debug mode using map[string]interface{}
+func LogWarning(correlationID, event string, additionalFields map[string]interface{}) {
+	if !logrus.IsLevelEnabled(logrus.WarnLevel) {
+		return
+	}
+
+	fields := logrus.Fields{
+		"event":         event,
+		"correlationID": correlationID,
+		"status":        "warning",
+	}
+	setTimestamp(fields)
+	mergeFields(fields, additionalFields)
+
+	logrus.WithFields(fields).Warn("Warning occurred")
+}
+
 // LogOnce logs an event only once to prevent duplicate logs using map[string]interface{}
 func LogOnce(event string, err error, additionalFields map[string]interface{}) {
+	if !logrus.IsLevelEnabled(logrus.InfoLevel) {
+		return
+	}
+
 	mutex.Lock()
 	defer mutex.Unlock()
 
@@ -108,20 +259,71 @@ func LogOnce(event string, err error, additionalFields map[string]interface{}) {
 	}
 
 	fields := logrus.Fields{
-		"event":     event,
-		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"event": event,
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	setTimestamp(fields)
+	mergeFields(fields, additionalFields)
+
+	logrus.WithFields(fields).Info("Event logged once")
+	loggedEvents[logKey] = true
+}
+
+// LogOnceByContent logs an event only once per distinct payload using map[string]interface{}.
+// The dedup key combines the event with a hash of the error and sorted fields, so it can be re-armed with ResetLogOnceByPrefix(event)
+func LogOnceByContent(event string, err error, additionalFields map[string]interface{}) {
+	if !logrus.IsLevelEnabled(logrus.InfoLevel) {
+		return
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	logKey := event + ":" + contentHash(event, err, additionalFields)
+	if loggedEvents[logKey] {
+		return
+	}
+
+	fields := logrus.Fields{
+		"event": event,
 	}
 	if err != nil {
 		fields["error"] = err.Error()
 	}
+	setTimestamp(fields)
 	mergeFields(fields, additionalFields)
 
 	logrus.WithFields(fields).Info("Event logged once")
 	loggedEvents[logKey] = true
 }
 
+// contentHash hashes an event, its error and its fields in sorted key order
+func contentHash(event string, err error, additionalFields map[string]interface{}) string {
+	keys := make([]string, 0, len(additionalFields))
+	for k := range additionalFields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "event=%s\n", event)
+	if err != nil {
+		fmt.Fprintf(h, "error=%s\n", err.Error())
+	}
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%v\n", k, additionalFields[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // LogSuccess logs a successful event only once to prevent duplicate logs using map[string]interface{}
 func LogSuccess(event string, additionalFields map[string]interface{}) {
+	if !logrus.IsLevelEnabled(logrus.InfoLevel) {
+		return
+	}
+
 	mutex.Lock()
 	defer mutex.Unlock()
 
@@ -131,33 +333,54 @@ func LogSuccess(event string, additionalFields map[string]interface{}) {
 	}
 
 	fields := logrus.Fields{
-		"event":     event,
-		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"event": event,
 	}
+	setTimestamp(fields)
 	mergeFields(fields, additionalFields)
 
 	logrus.WithFields(fields).Info("Event logged successfully")
 	loggedEvents[logKey] = true
 }
 
+// ResetLogOnceCache clears the LogOnce/LogSuccess cache so every event can be logged again
+func ResetLogOnceCache() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	loggedEvents = make(map[string]bool)
+}
+
+// ResetLogOnceByPrefix removes the cached events whose key starts with prefix so they can be logged again
+func ResetLogOnceByPrefix(prefix string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for logKey := range loggedEvents {
+		if strings.HasPrefix(logKey, prefix) {
+			delete(loggedEvents, logKey)
+		}
+	}
+}
+
 // LogRelationalStartNew logs the start of an event if debug mode is enabled using struct
 func LogRelationalStartNew(correlationID, event string, fields LogFields) *logrus.Entry {
-	if !debugMode {
+	if !debugMode || !logrus.IsLevelEnabled(logrus.InfoLevel) {
 		return nil
 	}
 
 	fields.Event = event
 	fields.CorrelationID = correlationID
-	fields.Timestamp = time.Now().UTC().Format(time.RFC3339)
 	fields.Status = "started"
 
-	entry := logrus.WithFields(logrus.Fields{
+	baseFields := logrus.Fields{
 		"event":         fields.Event,
 		"correlationID": fields.CorrelationID,
-		"timestamp":     fields.Timestamp,
 		"status":        fields.Status,
-	})
-	mergeFieldsNew(entry, fields.Additional)
+	}
+	setTimestamp(baseFields)
+
+	entry := logrus.WithFields(baseFields)
+	entry = mergeFieldsNew(entry, fields.Additional)
 
 	entry.Info("Event started")
 	return entry
@@ -165,22 +388,23 @@ func LogRelationalStartNew(correlationID, event string, fields LogFields) *logru
 
 // LogRelationalEndNew logs the end of an event if debug mode is enabled using struct
 func LogRelationalEndNew(correlationID, event string, fields LogFields) *logrus.Entry {
-	if !debugMode {
+	if !debugMode || !logrus.IsLevelEnabled(logrus.InfoLevel) {
 		return nil
 	}
 
 	fields.Event = event
 	fields.CorrelationID = correlationID
-	fields.Timestamp = time.Now().UTC().Format(time.RFC3339)
 	fields.Status = "completed"
 
-	entry := logrus.WithFields(logrus.Fields{
+	baseFields := logrus.Fields{
 		"event":         fields.Event,
 		"correlationID": fields.CorrelationID,
-		"timestamp":     fields.Timestamp,
 		"status":        fields.Status,
-	})
-	mergeFieldsNew(entry, fields.Additional)
+	}
+	setTimestamp(baseFields)
+
+	entry := logrus.WithFields(baseFields)
+	entry = mergeFieldsNew(entry, fields.Additional)
 
 	entry.Info("Event completed")
 	return entry
@@ -188,26 +412,36 @@ func LogRelationalEndNew(correlationID, event string, fields LogFields) *logrus.
 
 // LogErrorNew logs an error event regardless of debug mode using struct
 func LogErrorNew(correlationID, event string, err error, fields LogFields) {
+	if !logrus.IsLevelEnabled(logrus.ErrorLevel) {
+		return
+	}
+
 	fields.Event = event
 	fields.CorrelationID = correlationID
-	fields.Timestamp = time.Now().UTC().Format(time.RFC3339)
 	fields.Error = err.Error()
 	fields.Status = "error"
 
-	entry := logrus.WithFields(logrus.Fields{
+	baseFields := logrus.Fields{
 		"event":         fields.Event,
 		"correlationID": fields.CorrelationID,
-		"timestamp":     fields.Timestamp,
 		"error":         fields.Error,
+		"fingerprint":   ErrorFingerprint(err),
 		"status":        fields.Status,
-	})
-	mergeFieldsNew(entry, fields.Additional)
+	}
+	setTimestamp(baseFields)
+
+	entry := logrus.WithFields(baseFields)
+	entry = mergeFieldsNew(entry, fields.Additional)
 
 	entry.Error("Error occurred")
 }
 
 // LogOnceNew logs an event only once to prevent duplicate logs using struct
 func LogOnceNew(event string, err error, fields LogFields) {
+	if !logrus.IsLevelEnabled(logrus.InfoLevel) {
+		return
+	}
+
 	mutex.Lock()
 	defer mutex.Unlock()
 
@@ -217,16 +451,17 @@ func LogOnceNew(event string, err error, fields LogFields) {
 	}
 
 	fields.Event = event
-	fields.Timestamp = time.Now().UTC().Format(time.RFC3339)
 	if err != nil {
 		fields.Error = err.Error()
 	}
 
-	entry := logrus.WithFields(logrus.Fields{
-		"event":     fields.Event,
-		"timestamp": fields.Timestamp,
-	})
-	mergeFieldsNew(entry, fields.Additional)
+	baseFields := logrus.Fields{
+		"event": fields.Event,
+	}
+	setTimestamp(baseFields)
+
+	entry := logrus.WithFields(baseFields)
+	entry = mergeFieldsNew(entry, fields.Additional)
 
 	entry.Info("Event logged once")
 	loggedEvents[logKey] = true
@@ -234,6 +469,10 @@ func LogOnceNew(event string, err error, fields LogFields) {
 
 // LogSuccessNew logs a successful event only once to prevent duplicate logs using struct
 func LogSuccessNew(event string, fields LogFields) {
+	if !logrus.IsLevelEnabled(logrus.InfoLevel) {
+		return
+	}
+
 	mutex.Lock()
 	defer mutex.Unlock()
 
@@ -243,30 +482,45 @@ func LogSuccessNew(event string, fields LogFields) {
 	}
 
 	fields.Event = event
-	fields.Timestamp = time.Now().UTC().Format(time.RFC3339)
 
-	entry := logrus.WithFields(logrus.Fields{
-		"event":     fields.Event,
-		"timestamp": fields.Timestamp,
-	})
-	mergeFieldsNew(entry, fields.Additional)
+	baseFields := logrus.Fields{
+		"event": fields.Event,
+	}
+	setTimestamp(baseFields)
+
+	entry := logrus.WithFields(baseFields)
+	entry = mergeFieldsNew(entry, fields.Additional)
 
 	entry.Info("Event logged successfully")
 	loggedEvents[logKey] = true
 }
 
+// setTimestamp adds the current UTC time as the "timestamp" field if timestamp injection is enabled
+func setTimestamp(fields logrus.Fields) {
+	if injectTimestamp {
+		fields["timestamp"] = time.Now().UTC().Format(time.RFC3339)
+	}
+}
+
 // mergeFields merges additional fields into the base log fields (for map[string]interface{})
 func mergeFields(baseFields logrus.Fields, additionalFields map[string]interface{}) {
 	for k, v := range additionalFields {
-		baseFields[k] = v
+		if !fieldAllowed(k) {
+			continue
+		}
+		baseFields[k] = safeFieldValue(resolveLazy(v))
 	}
 }
 
-// mergeFieldsNew merges additional fields into the base log fields (for LogFields struct)
-func mergeFieldsNew(entry *logrus.Entry, additionalFields map[string]interface{}) {
+// mergeFieldsNew merges additional fields into the base log fields (for LogFields struct) and returns the enriched entry
+func mergeFieldsNew(entry *logrus.Entry, additionalFields map[string]interface{}) *logrus.Entry {
 	if additionalFields != nil {
 		for k, v := range additionalFields {
-			entry = entry.WithField(k, v)
+			if !fieldAllowed(k) {
+				continue
+			}
+			entry = entry.WithField(k, safeFieldValue(resolveLazy(v)))
 		}
 	}
+	return entry
 }
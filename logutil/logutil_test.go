@@ -0,0 +1,124 @@
+package logutil
+
+import (
+	"io"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// entryRecorder is a hook that keeps the data of every entry fired while it is installed
+type entryRecorder struct {
+	mu      sync.Mutex
+	entries []logrus.Fields
+}
+
+func (r *entryRecorder) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (r *entryRecorder) Fire(entry *logrus.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data := make(logrus.Fields, len(entry.Data))
+	for k, v := range entry.Data {
+		data[k] = v
+	}
+	r.entries = append(r.entries, data)
+	return nil
+}
+
+// recordEntries routes the standard logger to a recorder for the duration of the test
+func recordEntries(t *testing.T) *entryRecorder {
+	t.Helper()
+
+	logger := logrus.StandardLogger()
+	out, hooks, level := logger.Out, logger.ReplaceHooks(make(logrus.LevelHooks)), logger.GetLevel()
+	rec := &entryRecorder{}
+	logger.AddHook(rec)
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.InfoLevel)
+
+	t.Cleanup(func() {
+		logger.ReplaceHooks(hooks)
+		logger.SetOutput(out)
+		logger.SetLevel(level)
+	})
+	return rec
+}
+
+func TestNewVariantsKeepAdditionalFields(t *testing.T) {
+	rec := recordEntries(t)
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+	ResetLogOnceCache()
+
+	fields := LogFields{Additional: map[string]interface{}{"orderID": "o-1"}}
+	LogRelationalStartNew("c-1", "new_variants_start", fields)
+	LogRelationalEndNew("c-1", "new_variants_end", fields)
+	LogErrorNew("c-1", "new_variants_error", io.EOF, fields)
+	LogOnceNew("new_variants_once", nil, fields)
+	LogSuccessNew("new_variants_success", fields)
+
+	if len(rec.entries) != 5 {
+		t.Fatalf("got %d entries, want 5", len(rec.entries))
+	}
+	for _, entry := range rec.entries {
+		if entry["orderID"] != "o-1" {
+			t.Errorf("entry %v is missing the additional field orderID", entry["event"])
+		}
+	}
+}
+
+func TestLazyFieldsSkippedBelowLevel(t *testing.T) {
+	rec := recordEntries(t)
+	logrus.SetLevel(logrus.WarnLevel)
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+
+	evaluated := 0
+	fields := map[string]interface{}{"payload": Lazy(func() interface{} {
+		evaluated++
+		return "expensive"
+	})}
+
+	LogRelationalStart("c-1", "lazy_start", fields)
+	LogOnce("lazy_once", nil, fields)
+	LogSuccess("lazy_success", fields)
+	NewLogger(logrus.StandardLogger()).LogOnce("lazy_logger_once", nil, fields)
+
+	if evaluated != 0 {
+		t.Fatalf("lazy field evaluated %d times for Info calls at level Warn", evaluated)
+	}
+	if len(rec.entries) != 0 {
+		t.Fatalf("got %d entries, want none", len(rec.entries))
+	}
+
+	LogWarning("c-1", "lazy_warning", fields)
+	if evaluated != 1 {
+		t.Fatalf("lazy field evaluated %d times for an enabled Warn call, want 1", evaluated)
+	}
+}
+
+func TestInitWithoutAppEnvLogsJSON(t *testing.T) {
+	t.Setenv("APP_ENV", "")
+	t.Setenv("LOG_FORMAT", "")
+	os.Unsetenv("APP_ENV")
+	os.Unsetenv("LOG_FORMAT")
+
+	logger := logrus.StandardLogger()
+	out, formatter, level := logger.Out, logger.Formatter, logger.GetLevel()
+	t.Cleanup(func() {
+		logger.SetOutput(out)
+		logger.SetFormatter(formatter)
+		logger.SetLevel(level)
+	})
+
+	Init()
+	if _, ok := logger.Formatter.(*logrus.JSONFormatter); !ok {
+		t.Fatalf("Init without APP_ENV installed %T, want *logrus.JSONFormatter", logger.Formatter)
+	}
+}
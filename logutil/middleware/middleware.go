@@ -0,0 +1,207 @@
+// Package middleware provides HTTP middleware that ties requests to logutil correlation IDs.
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+	"github.com/Ehsan-Eghbali/common/response"
+)
+
+// CorrelationIDHeader is the request and response header carrying the correlation ID
+const CorrelationIDHeader = logutil.CorrelationIDHeader
+
+// Option configures the logging middleware
+type Option func(*config)
+
+// config holds the settings collected from Option values
+type config struct {
+	maxBodyBytes   int
+	requestSummary bool
+	dedupErrors    bool
+}
+
+// LogBodies captures up to maxBytes of the request and response bodies and logs them, redacted, at debug level.
+// This is off by default; bodies can hold personal data, so only enable it while debugging
+func LogBodies(maxBytes int) Option {
+	return func(c *config) {
+		c.maxBodyBytes = maxBytes
+	}
+}
+
+// RequestSummary logs one summary line per request with its status and the number of errors and warnings
+// logged under its correlation ID, see logutil.LogRequestSummary
+func RequestSummary() Option {
+	return func(c *config) {
+		c.requestSummary = true
+	}
+}
+
+// DedupErrors logs each distinct error only once per request and reports how often it repeated when
+// the request ends, see logutil.ContextWithErrorDedup
+func DedupErrors() Option {
+	return func(c *config) {
+		c.dedupErrors = true
+	}
+}
+
+// Logging returns middleware that stores the request's correlation ID in its context and logs the start and end of the request.
+// The start is only logged in debug mode; the completion is an access log at info level with the status, size and duration.
+// The correlation ID is taken from the X-Correlation-ID header or generated, and echoed back on the response
+func Logging(opts ...Option) func(http.Handler) http.Handler {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			correlationID := r.Header.Get(CorrelationIDHeader)
+			if correlationID == "" {
+				correlationID = logutil.GenerateCorrelationID()
+			}
+			w.Header().Set(CorrelationIDHeader, correlationID)
+			ctx := logutil.ContextWithCorrelationID(r.Context(), correlationID)
+			ctx = logutil.ContextWithFieldBag(ctx)
+			if cfg.requestSummary {
+				ctx = logutil.ContextWithRequestSummary(ctx)
+			}
+			if cfg.dedupErrors {
+				ctx = logutil.ContextWithErrorDedup(ctx)
+			}
+			r = r.WithContext(ctx)
+
+			fields := map[string]interface{}{
+				"method": r.Method,
+				"path":   r.URL.Path,
+			}
+			logutil.LogRelationalStart(correlationID, "http_request", fields)
+
+			var requestBody []byte
+			if cfg.maxBodyBytes > 0 && r.Body != nil {
+				requestBody = teeRequestBody(r, cfg.maxBodyBytes)
+			}
+
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK, maxBodyBytes: cfg.maxBodyBytes}
+			start := time.Now()
+			next.ServeHTTP(rec, r)
+			elapsed := time.Since(start)
+
+			if cfg.maxBodyBytes > 0 {
+				logutil.LogDebug(correlationID, "http_bodies", map[string]interface{}{
+					"request_body":  logutil.RedactJSON(requestBody),
+					"response_body": logutil.RedactJSON(rec.body.Bytes()),
+				})
+			}
+
+			for k, v := range logutil.FieldsFromContext(r.Context()) {
+				fields[k] = v
+			}
+			fields["statusCode"] = rec.status
+			fields["response_bytes"] = rec.bytes
+			fields["duration"] = elapsed.String()
+			fields["durationMs"] = elapsed.Milliseconds()
+			fields["status"] = "completed"
+			logutil.LogInfo(correlationID, "http_request", fields)
+
+			if threshold := response.LargeResponseThreshold(); threshold > 0 && rec.bytes > int64(threshold) {
+				logutil.LogWarning(correlationID, "large_response", map[string]interface{}{
+					"method":         r.Method,
+					"path":           r.URL.Path,
+					"response_bytes": rec.bytes,
+					"threshold":      threshold,
+				})
+			}
+
+			if cfg.dedupErrors {
+				logutil.FlushErrorDedup(r.Context())
+			}
+			if cfg.requestSummary {
+				logutil.LogRequestSummary(r.Context(), rec.status)
+			}
+		})
+	}
+}
+
+// teeRequestBody reads up to maxBytes of the request body and re-buffers it so the handler still sees the full body
+func teeRequestBody(r *http.Request, maxBytes int) []byte {
+	captured, _ := io.ReadAll(io.LimitReader(r.Body, int64(maxBytes)))
+	r.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(captured), r.Body),
+		Closer: r.Body,
+	}
+	return captured
+}
+
+// readCloser pairs a replacement reader with the original body's Close
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// recorder captures the status code, the number of body bytes written and up to maxBodyBytes of the body
+type recorder struct {
+	http.ResponseWriter
+	status       int
+	wroteHeader  bool
+	bytes        int64
+	maxBodyBytes int
+	body         bytes.Buffer
+}
+
+// WriteHeader records the status code before passing it on
+func (rec *recorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status = status
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Write captures the start of the body before passing it on
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	if remaining := rec.maxBodyBytes - rec.body.Len(); remaining > 0 {
+		if len(b) < remaining {
+			remaining = len(b)
+		}
+		rec.body.Write(b[:remaining])
+	}
+	n, err := rec.ResponseWriter.Write(b)
+	rec.bytes += int64(n)
+	return n, err
+}
+
+// Flush forwards to the underlying writer when it supports streaming
+func (rec *recorder) Flush() {
+	if flusher, ok := rec.ResponseWriter.(http.Flusher); ok {
+		rec.wroteHeader = true
+		flusher.Flush()
+	}
+}
+
+// Hijack forwards to the underlying writer so WebSocket upgrades work through the middleware.
+// A hijacked connection is reported with status 101
+func (rec *recorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rec.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("middleware: %T does not support hijacking", rec.ResponseWriter)
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil && !rec.wroteHeader {
+		rec.status = http.StatusSwitchingProtocols
+		rec.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the underlying writer for http.ResponseController
+func (rec *recorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ehsan-Eghbali/common/logutil/logtest"
+)
+
+func TestLoggingEmitsAccessLogWithoutDebugMode(t *testing.T) {
+	hook, restore := logtest.Capture()
+	defer restore()
+
+	handler := Logging()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(http.Hijacker); !ok {
+			t.Error("recorder does not implement http.Hijacker")
+		}
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Flush through the response controller: %v", err)
+		}
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/orders", nil))
+
+	if !hook.Has("http_request", "completed") {
+		t.Fatalf("got entries %v, want the completed http_request access log", hook.All())
+	}
+	if hook.Has("http_request", "started") {
+		t.Fatal("the request start was logged outside debug mode")
+	}
+}
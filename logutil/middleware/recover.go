@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+	"github.com/Ehsan-Eghbali/common/response"
+)
+
+// ErrPanic is wrapped by the error logged for a recovered panic
+var ErrPanic = errors.New("panic recovered")
+
+// Recover returns middleware that turns a handler panic into a 500 response.
+// The panic is logged through LogError with the stack trace, the request's correlation ID, method and path,
+// so install it inside Logging to have the correlation ID available
+func Recover() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				correlationID := logutil.CorrelationIDFromContext(r.Context())
+				if correlationID == "" {
+					correlationID = r.Header.Get(CorrelationIDHeader)
+				}
+
+				err := fmt.Errorf("%w: %v", ErrPanic, recovered)
+				logutil.LogError(correlationID, "http_panic", err, map[string]interface{}{
+					"method": r.Method,
+					"path":   r.URL.Path,
+					"stack":  string(debug.Stack()),
+				})
+
+				_ = response.RespondWithError(r.Context(), w, http.StatusInternalServerError, "internal server error", err, correlationID)
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/Ehsan-Eghbali/common/logutil/otel
+
+go 1.23.3
+
+require (
+	github.com/sirupsen/logrus v1.9.3
+	go.opentelemetry.io/otel/log v0.8.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/otel v1.32.0 // indirect
+	go.opentelemetry.io/otel/metric v1.32.0 // indirect
+	go.opentelemetry.io/otel/trace v1.32.0 // indirect
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+)
@@ -0,0 +1,140 @@
+// Package otel bridges logrus entries to the OpenTelemetry logs API.
+// It lives in its own module so the OpenTelemetry dependency stays out of the core packages.
+package otel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	otellog "go.opentelemetry.io/otel/log"
+)
+
+// Hook emits every logrus entry as an OpenTelemetry log record, alongside the regular output
+type Hook struct {
+	logger otellog.Logger
+}
+
+// NewOTelHook returns a hook that converts entries into log records emitted through logger.
+// Install it with logrus.AddHook to dual-write to stdout and the OpenTelemetry collector
+func NewOTelHook(logger otellog.Logger) *Hook {
+	return &Hook{logger: logger}
+}
+
+// Levels makes the hook fire for every log level
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire converts entry into a log record with its severity, message and fields and emits it
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	var record otellog.Record
+	record.SetTimestamp(entry.Time)
+	record.SetObservedTimestamp(time.Now())
+	record.SetSeverity(severity(entry.Level))
+	record.SetSeverityText(entry.Level.String())
+	record.SetBody(otellog.StringValue(entry.Message))
+
+	attributes := make([]otellog.KeyValue, 0, len(entry.Data))
+	for key, value := range entry.Data {
+		attributes = append(attributes, otellog.KeyValue{Key: key, Value: attributeValue(value)})
+	}
+	record.AddAttributes(attributes...)
+
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	h.logger.Emit(ctx, record)
+	return nil
+}
+
+// severity maps a logrus level onto the OpenTelemetry severity scale
+func severity(level logrus.Level) otellog.Severity {
+	switch level {
+	case logrus.TraceLevel:
+		return otellog.SeverityTrace
+	case logrus.DebugLevel:
+		return otellog.SeverityDebug
+	case logrus.InfoLevel:
+		return otellog.SeverityInfo
+	case logrus.WarnLevel:
+		return otellog.SeverityWarn
+	case logrus.ErrorLevel:
+		return otellog.SeverityError
+	case logrus.FatalLevel:
+		return otellog.SeverityFatal
+	case logrus.PanicLevel:
+		return otellog.SeverityFatal4
+	}
+	return otellog.SeverityUndefined
+}
+
+// attributeValue converts a field value into the closest OpenTelemetry value, falling back to its string form
+func attributeValue(value interface{}) otellog.Value {
+	switch v := value.(type) {
+	case nil:
+		return otellog.Value{}
+	case string:
+		return otellog.StringValue(v)
+	case bool:
+		return otellog.BoolValue(v)
+	case int:
+		return otellog.IntValue(v)
+	case int8:
+		return otellog.Int64Value(int64(v))
+	case int16:
+		return otellog.Int64Value(int64(v))
+	case int32:
+		return otellog.Int64Value(int64(v))
+	case int64:
+		return otellog.Int64Value(v)
+	case uint:
+		return uintValue(uint64(v))
+	case uint8:
+		return otellog.Int64Value(int64(v))
+	case uint16:
+		return otellog.Int64Value(int64(v))
+	case uint32:
+		return otellog.Int64Value(int64(v))
+	case uint64:
+		return uintValue(v)
+	case float32:
+		return otellog.Float64Value(float64(v))
+	case float64:
+		return otellog.Float64Value(v)
+	case []byte:
+		return otellog.BytesValue(v)
+	case error:
+		return otellog.StringValue(v.Error())
+	case time.Time:
+		return otellog.StringValue(v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return otellog.StringValue(v.String())
+	case fmt.Stringer:
+		return otellog.StringValue(v.String())
+	case map[string]interface{}:
+		nested := make([]otellog.KeyValue, 0, len(v))
+		for key, value := range v {
+			nested = append(nested, otellog.KeyValue{Key: key, Value: attributeValue(value)})
+		}
+		return otellog.MapValue(nested...)
+	case []interface{}:
+		values := make([]otellog.Value, len(v))
+		for i, value := range v {
+			values[i] = attributeValue(value)
+		}
+		return otellog.SliceValue(values...)
+	}
+	return otellog.StringValue(fmt.Sprintf("%v", value))
+}
+
+// uintValue keeps unsigned values as integers when they fit and as strings when they would overflow
+func uintValue(v uint64) otellog.Value {
+	if v > math.MaxInt64 {
+		return otellog.StringValue(fmt.Sprintf("%d", v))
+	}
+	return otellog.Int64Value(int64(v))
+}
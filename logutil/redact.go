@@ -0,0 +1,85 @@
+package logutil
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+)
+
+// RedactedValue replaces the value of sensitive fields in logged payloads
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeys holds the lower-cased keys whose values must never be logged
+var (
+	sensitiveKeys = map[string]struct{}{
+		"password":      {},
+		"secret":        {},
+		"token":         {},
+		"access_token":  {},
+		"refresh_token": {},
+		"api_key":       {},
+		"authorization": {},
+		"cookie":        {},
+	}
+	sensitiveKeysMu sync.RWMutex
+)
+
+// SetSensitiveKeys replaces the list of keys whose values are redacted from logged payloads.
+// Keys are matched case-insensitively
+func SetSensitiveKeys(keys ...string) {
+	updated := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		updated[strings.ToLower(key)] = struct{}{}
+	}
+
+	sensitiveKeysMu.Lock()
+	sensitiveKeys = updated
+	sensitiveKeysMu.Unlock()
+}
+
+// IsSensitiveKey reports whether values stored under key must be redacted
+func IsSensitiveKey(key string) bool {
+	sensitiveKeysMu.RLock()
+	defer sensitiveKeysMu.RUnlock()
+
+	_, ok := sensitiveKeys[strings.ToLower(key)]
+	return ok
+}
+
+// RedactJSON returns body with the values of sensitive keys replaced at any depth.
+// Bodies that are not valid JSON cannot be inspected and are replaced entirely
+func RedactJSON(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return "[UNPARSEABLE BODY OMITTED]"
+	}
+
+	redacted, err := json.Marshal(redactValue(decoded))
+	if err != nil {
+		return "[UNPARSEABLE BODY OMITTED]"
+	}
+	return string(redacted)
+}
+
+// redactValue walks decoded JSON and replaces the values of sensitive keys
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if IsSensitiveKey(key) {
+				v[key] = RedactedValue
+				continue
+			}
+			v[key] = redactValue(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+	return value
+}
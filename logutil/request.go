@@ -0,0 +1,49 @@
+package logutil
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// RequestFields extracts the standard request fields for use as additional log fields:
+// method, path, query, user agent, client IP and content length.
+// Values of sensitive query parameters (see SetSensitiveKeys) are redacted. The client IP is taken from
+// RemoteAddr only, since forwarding headers can be spoofed by clients
+func RequestFields(r *http.Request) map[string]interface{} {
+	fields := map[string]interface{}{
+		"method":    r.Method,
+		"path":      r.URL.Path,
+		"userAgent": r.UserAgent(),
+		"clientIP":  remoteIP(r.RemoteAddr),
+	}
+	if query := redactQuery(r.URL.Query()); query != "" {
+		fields["query"] = query
+	}
+	if r.ContentLength >= 0 {
+		fields["contentLength"] = r.ContentLength
+	}
+	return fields
+}
+
+// redactQuery encodes query with the values of sensitive parameters redacted
+func redactQuery(query url.Values) string {
+	for key, values := range query {
+		if !IsSensitiveKey(key) {
+			continue
+		}
+		for i := range values {
+			values[i] = RedactedValue
+		}
+	}
+	return query.Encode()
+}
+
+// remoteIP strips the port from a RemoteAddr value, returning it unchanged if it has none
+func remoteIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
@@ -0,0 +1,181 @@
+package logutil
+
+import (
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runtimeFields is registered as a hook by the first EnableRuntimeFields call
+var (
+	runtimeFields     = &runtimeFieldsHook{}
+	runtimeFieldsOnce sync.Once
+)
+
+// RuntimeFieldsOption customizes the fields added by EnableRuntimeFields
+type RuntimeFieldsOption func(fields logrus.Fields)
+
+// WithGoVersion adds the Go runtime version as "go_version"
+func WithGoVersion() RuntimeFieldsOption {
+	return func(fields logrus.Fields) {
+		fields["go_version"] = runtime.Version()
+	}
+}
+
+// WithVersion adds the service version as "version", e.g. utils.GetBuildInfo().Version
+func WithVersion(version string) RuntimeFieldsOption {
+	return func(fields logrus.Fields) {
+		fields["version"] = version
+	}
+}
+
+// runtimeFieldsHook adds precomputed process metadata to every entry
+type runtimeFieldsHook struct {
+	mu     sync.RWMutex
+	fields logrus.Fields
+}
+
+// EnableRuntimeFields adds "hostname" and "pid" to every log entry.
+// The values are computed once per call; calling it again replaces them instead of installing a second hook
+func EnableRuntimeFields(opts ...RuntimeFieldsOption) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	fields := logrus.Fields{
+		"hostname": hostname,
+		"pid":      os.Getpid(),
+	}
+	for _, opt := range opts {
+		opt(fields)
+	}
+
+	runtimeFields.mu.Lock()
+	runtimeFields.fields = fields
+	runtimeFields.mu.Unlock()
+
+	runtimeFieldsOnce.Do(func() {
+		logrus.AddHook(runtimeFields)
+	})
+}
+
+// Levels makes the hook fire for every log level
+func (h *runtimeFieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire adds the cached runtime fields without overriding fields set by the caller
+func (h *runtimeFieldsHook) Fire(entry *logrus.Entry) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for k, v := range h.fields {
+		if _, exists := entry.Data[k]; !exists {
+			entry.Data[k] = v
+		}
+	}
+	return nil
+}
+
+// DefaultRuntimeStatsInterval is used by StartRuntimeStats when no positive interval is given
+const DefaultRuntimeStatsInterval = 60 * time.Second
+
+// StartRuntimeStats logs memory and scheduler statistics at info level every interval.
+// Each tick calls runtime.ReadMemStats, which briefly stops the world, so keep the interval
+// in the order of a minute (a non-positive interval uses DefaultRuntimeStatsInterval).
+// The returned stop function ends the ticker goroutine
+func StartRuntimeStats(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		interval = DefaultRuntimeStatsInterval
+	}
+
+	return startTicker(interval, func() {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+
+		fields := logrus.Fields{
+			"event":        "runtime_stats",
+			"alloc":        stats.Alloc,
+			"heap_objects": stats.HeapObjects,
+			"num_gc":       stats.NumGC,
+			"goroutines":   runtime.NumGoroutine(),
+		}
+		setTimestamp(fields)
+
+		logrus.WithFields(fields).Info("Runtime stats")
+	})
+}
+
+// DefaultGCPausePollInterval is how often EnableGCPauseLogging reads the GC pause buffer unless
+// WithGCPausePollInterval sets another interval
+const DefaultGCPausePollInterval = 10 * time.Second
+
+// GCPauseOption customizes EnableGCPauseLogging
+type GCPauseOption func(cfg *gcPauseConfig)
+
+// gcPauseConfig holds the settings collected from GCPauseOption values
+type gcPauseConfig struct {
+	interval time.Duration
+}
+
+// WithGCPausePollInterval reads the GC pause buffer every interval instead of DefaultGCPausePollInterval.
+// A non-positive interval keeps the default
+func WithGCPausePollInterval(interval time.Duration) GCPauseOption {
+	return func(cfg *gcPauseConfig) {
+		if interval > 0 {
+			cfg.interval = interval
+		}
+	}
+}
+
+// EnableGCPauseLogging logs a warning for every garbage collection pause longer than threshold.
+// Pauses are read from runtime.MemStats.PauseNs on every poll; each GC cycle is reported at most
+// once, and cycles that have already left the 256-entry buffer are skipped.
+// Every poll calls runtime.ReadMemStats, which stops the world for a moment, so a short interval
+// trades latency for timeliness; with more than 256 collections per interval some pauses are missed.
+// The returned stop function ends the polling goroutine
+func EnableGCPauseLogging(threshold time.Duration, opts ...GCPauseOption) (stop func()) {
+	cfg := &gcPauseConfig{interval: DefaultGCPausePollInterval}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	lastNumGC := stats.NumGC
+
+	return startTicker(cfg.interval, func() {
+		runtime.ReadMemStats(&stats)
+
+		bufferSize := uint32(len(stats.PauseNs))
+		first := lastNumGC + 1
+		if stats.NumGC > bufferSize && first <= stats.NumGC-bufferSize {
+			first = stats.NumGC - bufferSize + 1
+		}
+
+		// GC cycle n (counting from 1) stores its pause at PauseNs[(n+255)%256]
+		for gc := first; gc <= stats.NumGC; gc++ {
+			pause := time.Duration(stats.PauseNs[(gc+bufferSize-1)%bufferSize])
+			if pause <= threshold {
+				continue
+			}
+
+			fields := logrus.Fields{
+				"event":     "gc_pause",
+				"status":    "warning",
+				"gc":        gc,
+				"pause":     pause.String(),
+				"pauseMs":   float64(pause) / float64(time.Millisecond),
+				"threshold": threshold.String(),
+			}
+			setTimestamp(fields)
+
+			logrus.WithFields(fields).Warn("Slow GC pause")
+		}
+		lastNumGC = stats.NumGC
+	})
+}
@@ -0,0 +1,60 @@
+package logutil
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// unserializableWarned remembers the types already reported as unserializable
+var unserializableWarned sync.Map
+
+// safeFieldValue returns v, or a "<unserializable: type>" placeholder when v cannot be marshaled to JSON.
+// Only values that can fail are checked, to keep plain fields off a second encoding pass: channels, funcs
+// and complex numbers are replaced outright, and custom json.Marshaler or encoding.TextMarshaler
+// implementations are test-encoded with a panicking MarshalJSON recovered so it cannot take down the formatter
+func safeFieldValue(v interface{}) (out interface{}) {
+	switch v.(type) {
+	case nil, string, bool, error,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return v
+	case json.Marshaler, encoding.TextMarshaler:
+	default:
+		switch reflect.TypeOf(v).Kind() {
+		case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+			return unserializable(fmt.Sprintf("%T", v), errors.New("unsupported type"))
+		}
+		return v
+	}
+
+	typeName := fmt.Sprintf("%T", v)
+	defer func() {
+		if r := recover(); r != nil {
+			out = unserializable(typeName, fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	if _, err := json.Marshal(v); err != nil {
+		return unserializable(typeName, err)
+	}
+	return v
+}
+
+// unserializable builds the placeholder for a value of typeName and warns once per type
+func unserializable(typeName string, cause error) string {
+	if _, warned := unserializableWarned.LoadOrStore(typeName, true); !warned {
+		logrus.WithFields(logrus.Fields{
+			"event": "unserializable_log_field",
+			"type":  typeName,
+			"error": cause.Error(),
+		}).Warn("Log field replaced with placeholder")
+	}
+	return "<unserializable: " + typeName + ">"
+}
@@ -0,0 +1,43 @@
+package logutil
+
+import (
+	"strings"
+	"testing"
+)
+
+type panickingMarshaler struct{}
+
+func (panickingMarshaler) MarshalJSON() ([]byte, error) {
+	panic("boom")
+}
+
+func TestSafeFieldValue(t *testing.T) {
+	recordEntries(t)
+
+	type plain struct{ Name string }
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		placeholder bool
+	}{
+		{"string", "a", false},
+		{"struct", plain{Name: "a"}, false},
+		{"map", map[string]interface{}{"a": 1}, false},
+		{"chan", make(chan int), true},
+		{"func", func() {}, true},
+		{"complex", complex(1, 2), true},
+		{"panicking marshaler", panickingMarshaler{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := safeFieldValue(tt.value)
+			text, isString := out.(string)
+			gotPlaceholder := isString && strings.HasPrefix(text, "<unserializable: ")
+			if gotPlaceholder != tt.placeholder {
+				t.Fatalf("safeFieldValue(%T) = %v, placeholder %v, want %v", tt.value, out, gotPlaceholder, tt.placeholder)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package logutil
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MaskedValue replaces the value of struct fields tagged log:"mask" by Sanitize
+const MaskedValue = "***"
+
+// Sanitize returns a copy of v that is safe to log, honoring `log` struct tags at any depth:
+// log:"-" omits the field and log:"mask" replaces its value with MaskedValue.
+// Structs become maps keyed like their JSON encoding; types with their own JSON or text
+// encoding (such as time.Time) are kept as they are, and references back into a value being
+// sanitized are replaced with "<cycle>"
+func Sanitize(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return sanitizeValue(reflect.ValueOf(v))
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// cycleValue replaces a pointer or map that refers back to a value Sanitize is already inside of
+const cycleValue = "<cycle>"
+
+// sanitizer walks one value passed to Sanitize, remembering the pointers and maps on the current path
+type sanitizer struct {
+	visiting map[uintptr]struct{}
+}
+
+// sanitizeValue walks rv and rebuilds it with tagged struct fields omitted or masked
+func sanitizeValue(rv reflect.Value) interface{} {
+	s := sanitizer{visiting: make(map[uintptr]struct{})}
+	return s.value(rv)
+}
+
+// value sanitizes rv, emitting cycleValue instead of following a reference back into the current path
+func (s sanitizer) value(rv reflect.Value) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+	if rv.CanInterface() && (rv.Type().Implements(jsonMarshalerType) || rv.Type().Implements(textMarshalerType)) {
+		return rv.Interface()
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map:
+		if rv.IsNil() {
+			return nil
+		}
+		ptr := rv.Pointer()
+		if _, seen := s.visiting[ptr]; seen {
+			return cycleValue
+		}
+		s.visiting[ptr] = struct{}{}
+		defer delete(s.visiting, ptr)
+
+		if rv.Kind() == reflect.Pointer {
+			return s.value(rv.Elem())
+		}
+		out := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, ok := mapKeyString(iter.Key())
+			if !ok {
+				return leafValue(rv)
+			}
+			out[key] = s.value(iter.Value())
+		}
+		return out
+	case reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return s.value(rv.Elem())
+	case reflect.Struct:
+		return s.structValue(rv)
+	case reflect.Slice:
+		if rv.IsNil() {
+			return nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return leafValue(rv)
+		}
+		fallthrough
+	case reflect.Array:
+		out := make([]interface{}, rv.Len())
+		for i := range out {
+			out[i] = s.value(rv.Index(i))
+		}
+		return out
+	}
+	return leafValue(rv)
+}
+
+// structValue converts a struct into a map of its fields, applying log tags. Like encoding/json it
+// promotes the fields of embedded structs, exported or not, with the outer struct's own fields winning
+func (s sanitizer) structValue(rv reflect.Value) map[string]interface{} {
+	rt := rv.Type()
+	out := make(map[string]interface{}, rt.NumField())
+	var promoted []map[string]interface{}
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Anonymous && !hasJSONName(field) && isStructOrStructPointer(field.Type) {
+			if field.Tag.Get("log") == "-" || field.Tag.Get("json") == "-" {
+				continue
+			}
+			if embedded, ok := s.value(rv.Field(i)).(map[string]interface{}); ok {
+				promoted = append(promoted, embedded)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name := jsonFieldName(field)
+		if name == "" {
+			continue
+		}
+
+		switch field.Tag.Get("log") {
+		case "-":
+			continue
+		case "mask":
+			out[name] = MaskedValue
+			continue
+		}
+		out[name] = s.value(rv.Field(i))
+	}
+
+	for _, embedded := range promoted {
+		for k, v := range embedded {
+			if _, exists := out[k]; !exists {
+				out[k] = v
+			}
+		}
+	}
+	return out
+}
+
+// isStructOrStructPointer reports whether t is a struct or a pointer to one
+func isStructOrStructPointer(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// leafValue returns rv as an interface value. Values reached through unexported embedded structs
+// cannot be returned directly, so their basic kinds are copied and anything else is formatted
+func leafValue(rv reflect.Value) interface{} {
+	if rv.CanInterface() {
+		return rv.Interface()
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	}
+	return fmt.Sprint(rv)
+}
+
+// jsonFieldName returns the key field is encoded under by encoding/json, or "" when it is skipped
+func jsonFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+// hasJSONName reports whether field sets an explicit name in its json tag
+func hasJSONName(field reflect.StructField) bool {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name != "" && name != "-"
+}
+
+// mapKeyString converts a map key to the string encoding/json would use
+func mapKeyString(key reflect.Value) (string, bool) {
+	if key.Kind() == reflect.String {
+		return key.String(), true
+	}
+	data, err := json.Marshal(leafValue(key))
+	if err != nil {
+		return "", false
+	}
+	return strings.Trim(string(data), `"`), true
+}
@@ -0,0 +1,43 @@
+package logutil
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync/atomic"
+)
+
+// spanIDBytes is the number of random bytes in a span ID, hex-encoded to twice as many characters
+var spanIDBytes atomic.Int32
+
+func init() {
+	spanIDBytes.Store(8)
+}
+
+// SetSpanIDLength sets how many random bytes GenerateSpanID uses; values below 1 are ignored
+func SetSpanIDLength(n int) {
+	if n < 1 {
+		return
+	}
+	spanIDBytes.Store(int32(n))
+}
+
+// GenerateSpanID generates a short hex identifier for a single operation within a correlation ID
+func GenerateSpanID() string {
+	b := make([]byte, spanIDBytes.Load())
+	if _, err := rand.Read(b); err != nil {
+		return GenerateCorrelationID()
+	}
+	return hex.EncodeToString(b)
+}
+
+// ContextWithSpanID returns a copy of ctx carrying the given span ID as the current span
+func ContextWithSpanID(ctx context.Context, spanID string) context.Context {
+	return context.WithValue(ctx, spanIDKey, spanID)
+}
+
+// SpanIDFromContext returns the current span ID stored in ctx, or an empty string if none is set
+func SpanIDFromContext(ctx context.Context) string {
+	spanID, _ := ctx.Value(spanIDKey).(string)
+	return spanID
+}
@@ -0,0 +1,88 @@
+package logutil
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// streamSplit becomes the standard logger's output once EnableStreamSplitting is called
+var (
+	streamSplit        = &streamSplitWriter{out: os.Stdout, errOut: os.Stderr}
+	streamSplitOnce    sync.Once
+	streamSplitEnabled atomic.Bool
+)
+
+// streamSplitWriter writes entries at ErrorLevel and above to errOut and everything else to out.
+// logrus formats and writes each entry under the logger's lock, so the level recorded by
+// streamLevelFormatter while formatting is the level of the bytes passed to the following Write
+type streamSplitWriter struct {
+	mu     sync.Mutex
+	out    io.Writer
+	errOut io.Writer
+	level  logrus.Level
+}
+
+// EnableStreamSplitting sends entries at ErrorLevel and above to stderr and all other entries to the
+// current output (stdout unless changed with SetOutput). Entries still go through the logger's formatter
+// and output; the output becomes a writer that picks the stream by the level of the entry being written
+func EnableStreamSplitting() {
+	streamSplitOnce.Do(func() {
+		logger := logrus.StandardLogger()
+
+		streamSplit.mu.Lock()
+		streamSplit.out = logger.Out
+		streamSplit.mu.Unlock()
+
+		formatMu.Lock()
+		defer formatMu.Unlock()
+
+		streamSplitEnabled.Store(true)
+		applyFormatter()
+		logger.SetOutput(streamSplit)
+	})
+}
+
+// SetOutput sets where logs are written. With stream splitting enabled it only changes the destination
+// of entries below ErrorLevel; errors keep going to stderr
+func SetOutput(w io.Writer) {
+	if streamSplitEnabled.Load() {
+		streamSplit.mu.Lock()
+		streamSplit.out = w
+		streamSplit.mu.Unlock()
+		return
+	}
+	logrus.SetOutput(w)
+}
+
+// Write writes p to the stream matching the level of the entry formatted last
+func (w *streamSplitWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.level <= logrus.ErrorLevel {
+		return w.errOut.Write(p)
+	}
+	return w.out.Write(p)
+}
+
+// setLevel records the level of the entry about to be written
+func (w *streamSplitWriter) setLevel(level logrus.Level) {
+	w.mu.Lock()
+	w.level = level
+	w.mu.Unlock()
+}
+
+// streamLevelFormatter tells streamSplit the level of each entry and then delegates to the wrapped formatter
+type streamLevelFormatter struct {
+	inner logrus.Formatter
+}
+
+// Format records the entry's level for streamSplit and formats it with the wrapped formatter
+func (f *streamLevelFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	streamSplit.setLevel(entry.Level)
+	return f.inner.Format(entry)
+}
@@ -0,0 +1,55 @@
+package logutil
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestStreamSplittingUsesLoggerFormatter(t *testing.T) {
+	logger := logrus.StandardLogger()
+	out, hooks, level := logger.Out, logger.ReplaceHooks(make(logrus.LevelHooks)), logger.GetLevel()
+	logger.SetLevel(logrus.InfoLevel)
+
+	EnableStreamSplitting()
+
+	var stdout, stderr bytes.Buffer
+	streamSplit.mu.Lock()
+	previousOut, previousErrOut := streamSplit.out, streamSplit.errOut
+	streamSplit.out, streamSplit.errOut = &stdout, &stderr
+	streamSplit.mu.Unlock()
+
+	t.Cleanup(func() {
+		streamSplit.mu.Lock()
+		streamSplit.out, streamSplit.errOut = previousOut, previousErrOut
+		streamSplit.mu.Unlock()
+
+		formatMu.Lock()
+		streamSplitEnabled.Store(false)
+		streamSplitOnce = sync.Once{}
+		applyFormatter()
+		formatMu.Unlock()
+
+		logger.ReplaceHooks(hooks)
+		logger.SetOutput(out)
+		logger.SetLevel(level)
+	})
+
+	if logger.Out == io.Discard {
+		t.Fatal("stream splitting discards the logger's output")
+	}
+
+	logrus.WithField("event", "split_info").Info("info message")
+	logrus.WithField("event", "split_error").Error("error message")
+
+	if !strings.Contains(stdout.String(), "split_info") || strings.Contains(stdout.String(), "split_error") {
+		t.Fatalf("stdout got %q, want only the info entry", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "split_error") || strings.Contains(stderr.String(), "split_info") {
+		t.Fatalf("stderr got %q, want only the error entry", stderr.String())
+	}
+}
@@ -0,0 +1,88 @@
+package logutil
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+// requestCounters maps correlation IDs of requests being summarized to their counters
+var (
+	requestCounters sync.Map
+	summaryHookOnce sync.Once
+)
+
+// requestCounter counts the errors and warnings logged for one request
+type requestCounter struct {
+	correlationID string
+	errors        atomic.Int64
+	warnings      atomic.Int64
+}
+
+// summaryHook counts warning and error entries against the request their correlationID field belongs to
+type summaryHook struct{}
+
+// Levels makes the hook fire for warnings and everything more severe
+func (summaryHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel, logrus.WarnLevel}
+}
+
+// Fire increments the counter of the request the entry was logged for, if it is being summarized
+func (summaryHook) Fire(entry *logrus.Entry) error {
+	correlationID, ok := entry.Data["correlationID"].(string)
+	if !ok || correlationID == "" {
+		return nil
+	}
+
+	value, ok := requestCounters.Load(correlationID)
+	if !ok {
+		return nil
+	}
+
+	counter := value.(*requestCounter)
+	if entry.Level == logrus.WarnLevel {
+		counter.warnings.Add(1)
+	} else {
+		counter.errors.Add(1)
+	}
+	return nil
+}
+
+// ContextWithRequestSummary starts counting the errors and warnings logged under the correlation ID stored in ctx.
+// Call LogRequestSummary with the returned context when the request ends to emit the counts and stop counting.
+// ctx must already carry a correlation ID, see ContextWithCorrelationID
+func ContextWithRequestSummary(ctx context.Context) context.Context {
+	summaryHookOnce.Do(func() {
+		logrus.AddHook(summaryHook{})
+	})
+
+	counter := &requestCounter{correlationID: CorrelationIDFromContext(ctx)}
+	if counter.correlationID != "" {
+		requestCounters.Store(counter.correlationID, counter)
+	}
+	return context.WithValue(ctx, requestSummaryKey, counter)
+}
+
+// LogRequestSummary logs one summary line for the request with its final status and the number of errors
+// and warnings logged while it was processed. It does nothing if ctx was not set up by ContextWithRequestSummary
+func LogRequestSummary(ctx context.Context, status int) {
+	counter, ok := ctx.Value(requestSummaryKey).(*requestCounter)
+	if !ok {
+		return
+	}
+	requestCounters.CompareAndDelete(counter.correlationID, counter)
+
+	fields := logrus.Fields{
+		"event":         "request_summary",
+		"correlationID": counter.correlationID,
+		"status":        "completed",
+		"statusCode":    status,
+		"errorCount":    counter.errors.Load(),
+		"warningCount":  counter.warnings.Load(),
+	}
+	setTimestamp(fields)
+
+	logrus.WithFields(fields).Info("Request summary")
+}
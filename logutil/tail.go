@@ -0,0 +1,116 @@
+package logutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// tailBuffer is registered as a hook by the first SetTailBufferSize call and stays registered afterwards
+var (
+	tailBuffer     = &tailHook{}
+	tailBufferOnce sync.Once
+)
+
+// tailHook is a logrus hook that keeps the most recent entries in a bounded ring buffer
+type tailHook struct {
+	mu      sync.Mutex
+	entries []map[string]interface{}
+	start   int
+	count   int
+}
+
+// SetTailBufferSize keeps the last n log entries in memory for TailHandler.
+// A size of zero or less disables the buffer and drops what it holds
+func SetTailBufferSize(n int) {
+	tailBufferOnce.Do(func() {
+		logrus.AddHook(tailBuffer)
+	})
+	tailBuffer.resize(n)
+}
+
+// TailHandler returns an HTTP handler that serves the buffered log entries as a JSON array, oldest first
+func TailHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		entries := tailBuffer.snapshot()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(entries)
+	}
+}
+
+// Levels makes the hook fire for every log level
+func (h *tailHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire records the entry, overwriting the oldest one once the buffer is full
+func (h *tailHook) Fire(entry *logrus.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	size := len(h.entries)
+	if size == 0 {
+		return nil
+	}
+
+	record := make(map[string]interface{}, len(entry.Data)+3)
+	for k, v := range entry.Data {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		record[k] = v
+	}
+	record["time"] = entry.Time.UTC().Format(time.RFC3339)
+	record["level"] = entry.Level.String()
+	record["msg"] = entry.Message
+
+	if h.count < size {
+		h.entries[(h.start+h.count)%size] = record
+		h.count++
+		return nil
+	}
+	h.entries[h.start] = record
+	h.start = (h.start + 1) % size
+	return nil
+}
+
+// resize changes the capacity, keeping the newest entries that still fit
+func (h *tailHook) resize(n int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if n < 0 {
+		n = 0
+	}
+	kept := h.ordered()
+	if len(kept) > n {
+		kept = kept[len(kept)-n:]
+	}
+
+	h.entries = make([]map[string]interface{}, n)
+	copy(h.entries, kept)
+	h.start = 0
+	h.count = len(kept)
+}
+
+// snapshot returns a copy of the buffered entries, oldest first
+func (h *tailHook) snapshot() []map[string]interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.ordered()
+}
+
+// ordered returns the buffered entries oldest first; the caller must hold h.mu
+func (h *tailHook) ordered() []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, h.count)
+	for i := 0; i < h.count; i++ {
+		out = append(out, h.entries[(h.start+i)%len(h.entries)])
+	}
+	return out
+}
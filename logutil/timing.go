@@ -0,0 +1,104 @@
+package logutil
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TimeFunc runs fn and logs its start and end together with the elapsed duration
+func TimeFunc(correlationID, event string, fn func() error) error {
+	_, err := TimeFuncResult(correlationID, event, func() (struct{}, error) {
+		return struct{}{}, fn()
+	})
+	return err
+}
+
+// TimeFuncResult runs fn like TimeFunc and returns the value it produced.
+// Start/end logs respect debug mode, errors are always logged through LogError
+func TimeFuncResult[T any](correlationID, event string, fn func() (T, error)) (T, error) {
+	return timeSpan(correlationID, event, GenerateSpanID(), "", fn)
+}
+
+// TimeFuncContext runs fn like TimeFunc, taking the correlation ID and parent span from ctx.
+// fn receives a context carrying the new span ID, so nested timed calls are logged as its children
+func TimeFuncContext(ctx context.Context, event string, fn func(ctx context.Context) error) error {
+	spanID := GenerateSpanID()
+	parentSpanID := SpanIDFromContext(ctx)
+	childCtx := ContextWithSpanID(ctx, spanID)
+
+	_, err := timeSpan(CorrelationIDFromContext(ctx), event, spanID, parentSpanID, func() (struct{}, error) {
+		return struct{}{}, fn(childCtx)
+	})
+	return err
+}
+
+// timeSpan runs fn and logs it as the span spanID, optionally linked to parentSpanID
+func timeSpan[T any](correlationID, event, spanID, parentSpanID string, fn func() (T, error)) (T, error) {
+	spanFields := map[string]interface{}{
+		"span_id": spanID,
+	}
+	if parentSpanID != "" {
+		spanFields["parent_span_id"] = parentSpanID
+	}
+	LogRelationalStart(correlationID, event, spanFields)
+
+	start := time.Now()
+	result, err := fn()
+	fields := durationFields(time.Since(start))
+	for k, v := range spanFields {
+		fields[k] = v
+	}
+
+	if err != nil {
+		LogError(correlationID, event, err, fields)
+		return result, err
+	}
+
+	LogRelationalEnd(correlationID, event, fields)
+	return result, nil
+}
+
+// durationFields builds the fields used to report an elapsed duration
+func durationFields(elapsed time.Duration) map[string]interface{} {
+	return map[string]interface{}{
+		"duration":   elapsed.String(),
+		"durationMs": elapsed.Milliseconds(),
+	}
+}
+
+// LogIfSlow runs fn and logs a warning only when it takes longer than threshold.
+// Fast calls produce no log; the error returned by fn is passed through unchanged
+func LogIfSlow(correlationID, event string, threshold time.Duration, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+
+	if elapsed > threshold {
+		fields := durationFields(elapsed)
+		fields["threshold"] = threshold.String()
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		LogWarning(correlationID, event, fields)
+	}
+	return err
+}
+
+// ContextWithLoggedTimeout works like context.WithTimeout but logs a warning with the event and timeout
+// if the deadline is reached before cancel is called. Calling cancel stops the watcher goroutine
+func ContextWithLoggedTimeout(parent context.Context, d time.Duration, event string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(parent, d)
+
+	go func() {
+		<-ctx.Done()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			LogWarning(CorrelationIDFromContext(ctx), event, map[string]interface{}{
+				"timeout": d.String(),
+				"error":   ctx.Err().Error(),
+			})
+		}
+	}()
+
+	return ctx, cancel
+}
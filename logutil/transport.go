@@ -0,0 +1,50 @@
+package logutil
+
+import (
+	"context"
+	"net/http"
+)
+
+// CorrelationIDHeader is the HTTP header used to propagate the correlation ID between services
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// tracingTransport injects the correlation ID into outbound requests
+type tracingTransport struct {
+	base                  http.RoundTripper
+	fallbackCorrelationID string
+}
+
+// NewTracingTransport wraps base so every outbound request carries the correlation ID from its context
+// in the X-Correlation-ID header. A nil base uses http.DefaultTransport
+func NewTracingTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &tracingTransport{base: base}
+}
+
+// HTTPClientWithTracing returns a client whose requests carry a correlation ID: the one in each
+// request's context, or otherwise the one stored in ctx
+func HTTPClientWithTracing(ctx context.Context) *http.Client {
+	return &http.Client{
+		Transport: &tracingTransport{
+			base:                  http.DefaultTransport,
+			fallbackCorrelationID: CorrelationIDFromContext(ctx),
+		},
+	}
+}
+
+// RoundTrip sets the correlation ID header on a copy of req and forwards it, leaving an existing header untouched
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	correlationID := CorrelationIDFromContext(req.Context())
+	if correlationID == "" {
+		correlationID = t.fallbackCorrelationID
+	}
+	if correlationID == "" || req.Header.Get(CorrelationIDHeader) != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	traced := req.Clone(req.Context())
+	traced.Header.Set(CorrelationIDHeader, correlationID)
+	return t.base.RoundTrip(traced)
+}
@@ -0,0 +1,80 @@
+package logutil
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// loggingTransport logs every outbound request it forwards
+type loggingTransport struct {
+	base http.RoundTripper
+}
+
+// NewLoggingTransport wraps base so every outbound request is logged as an "http_client_request" event:
+// start and end with method, host, path, status and duration through the timed-event API (debug mode only),
+// and transport failures through LogError. Sensitive headers and query parameters are redacted, see SetSensitiveKeys.
+// A nil base uses http.DefaultTransport
+func NewLoggingTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &loggingTransport{base: base}
+}
+
+// RoundTrip forwards req to the base transport and logs the outcome
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	correlationID := CorrelationIDFromContext(req.Context())
+	if !debugMode {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			LogError(correlationID, "http_client_request", err, outboundRequestFields(req))
+		}
+		return resp, err
+	}
+
+	fields := outboundRequestFields(req)
+	fields["headers"] = redactHeaders(req.Header)
+	LogRelationalStart(correlationID, "http_client_request", fields)
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	for k, v := range durationFields(time.Since(start)) {
+		fields[k] = v
+	}
+
+	if err != nil {
+		LogError(correlationID, "http_client_request", err, fields)
+		return resp, err
+	}
+
+	fields["statusCode"] = resp.StatusCode
+	LogRelationalEnd(correlationID, "http_client_request", fields)
+	return resp, nil
+}
+
+// outboundRequestFields builds the fields identifying an outbound request
+func outboundRequestFields(req *http.Request) map[string]interface{} {
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"host":   req.URL.Host,
+		"path":   req.URL.Path,
+	}
+	if query := redactQuery(req.URL.Query()); query != "" {
+		fields["query"] = query
+	}
+	return fields
+}
+
+// redactHeaders flattens headers into a map, redacting the values of sensitive ones
+func redactHeaders(header http.Header) map[string]string {
+	redacted := make(map[string]string, len(header))
+	for key, values := range header {
+		if IsSensitiveKey(key) {
+			redacted[key] = RedactedValue
+			continue
+		}
+		redacted[key] = strings.Join(values, ", ")
+	}
+	return redacted
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+// acceptedBody is the JSON shape of a 202 Accepted response.
+type acceptedBody struct {
+	StatusURL string      `json:"status_url,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// RespondWithAccepted sends 202 Accepted for an asynchronous job, pointing the Location header and the
+// body's status_url at the URL clients poll for the job status. data carries the job reference.
+// An empty or malformed statusURL is left out rather than written as an invalid header.
+func RespondWithAccepted(ctx context.Context, w http.ResponseWriter, statusURL string, data interface{}) error {
+	if statusURL != "" {
+		if u, err := url.Parse(statusURL); err != nil || (u.Scheme == "" && u.Path == "") {
+			logutil.LogWarning(TraceIDFromContext(ctx), "invalid_status_url", map[string]interface{}{
+				"status_url": statusURL,
+			})
+			statusURL = ""
+		}
+	}
+
+	if statusURL != "" {
+		w.Header().Set("Location", statusURL)
+	}
+	return RespondWithSuccess(ctx, w, http.StatusAccepted, acceptedBody{
+		StatusURL: statusURL,
+		Data:      data,
+	})
+}
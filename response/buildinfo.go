@@ -0,0 +1,20 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Ehsan-Eghbali/common/utils"
+)
+
+// RespondWithBuildInfo sends the build metadata and process uptime, for use on a /version endpoint.
+func RespondWithBuildInfo(ctx context.Context, w http.ResponseWriter) error {
+	return RespondWithSuccess(ctx, w, http.StatusOK, struct {
+		utils.BuildInfo
+		Uptime string `json:"uptime"`
+	}{
+		BuildInfo: utils.GetBuildInfo(),
+		Uptime:    utils.Uptime().Truncate(time.Second).String(),
+	})
+}
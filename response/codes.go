@@ -0,0 +1,123 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+// errorCode holds the HTTP status and default message registered for an application error code.
+type errorCode struct {
+	Status  int
+	Message string
+}
+
+// registeredError links a sentinel error to an application error code.
+type registeredError struct {
+	target error
+	code   string
+}
+
+var (
+	errorCodes       = make(map[string]errorCode)
+	registeredErrors []registeredError
+	errorCodesMu     sync.RWMutex
+)
+
+// RegisterErrorCode registers the HTTP status and default message for an application error code.
+// Registering an existing code overwrites it.
+func RegisterErrorCode(code string, status int, defaultMessage string) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+
+	errorCodes[code] = errorCode{Status: status, Message: defaultMessage}
+}
+
+// RegisterError maps errors matching target (per errors.Is) to an application error code
+// registered with RegisterErrorCode. Targets are checked in registration order.
+func RegisterError(target error, code string) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+
+	registeredErrors = append(registeredErrors, registeredError{target: target, code: code})
+}
+
+// ErrorCodeFor returns the code registered for the first sentinel that err matches anywhere in its chain.
+func ErrorCodeFor(err error) (string, bool) {
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+
+	for _, registered := range registeredErrors {
+		if errors.Is(err, registered.target) {
+			return registered.code, true
+		}
+	}
+	return "", false
+}
+
+// lookupErrorCode returns the registered status and message for code.
+// Unknown codes resolve to 500 with a generic message.
+func lookupErrorCode(code string) (errorCode, bool) {
+	errorCodesMu.RLock()
+	entry, ok := errorCodes[code]
+	errorCodesMu.RUnlock()
+
+	if !ok {
+		return errorCode{
+			Status:  http.StatusInternalServerError,
+			Message: http.StatusText(http.StatusInternalServerError),
+		}, false
+	}
+	return entry, true
+}
+
+// RespondWithCode sends a standardized JSON error response using the status and message registered for code.
+func RespondWithCode(ctx context.Context, w http.ResponseWriter, code string, err error, traceID string) error {
+	traceID = resolveTraceID(ctx, traceID)
+
+	entry, ok := lookupErrorCode(code)
+	if !ok {
+		logutil.LogWarning(traceID, "unknown_error_code", map[string]interface{}{
+			"code": code,
+		})
+		code = ErrorCodeForStatus(entry.Status)
+	}
+
+	return writeError(ctx, w, entry.Status, code, entry.Message, err, traceID)
+}
+
+// statusErrorCodes maps HTTP statuses to their machine-readable error codes.
+var statusErrorCodes = map[int]string{
+	http.StatusBadRequest:            "bad_request",
+	http.StatusUnauthorized:          "unauthorized",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not_found",
+	http.StatusMethodNotAllowed:      "method_not_allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusGone:                  "gone",
+	http.StatusPreconditionFailed:    "precondition_failed",
+	http.StatusRequestEntityTooLarge: "payload_too_large",
+	http.StatusUnsupportedMediaType:  "unsupported_media_type",
+	http.StatusUnprocessableEntity:   "unprocessable_entity",
+	http.StatusTooManyRequests:       "too_many_requests",
+	http.StatusInternalServerError:   "internal",
+	http.StatusNotImplemented:        "not_implemented",
+	http.StatusBadGateway:            "bad_gateway",
+	http.StatusServiceUnavailable:    "unavailable",
+	http.StatusGatewayTimeout:        "timeout",
+}
+
+// ErrorCodeForStatus returns the stable machine-readable error code for an HTTP status.
+// Unlisted statuses fall back to "client_error" for 4xx and "internal" for everything else.
+func ErrorCodeForStatus(status int) string {
+	if code, ok := statusErrorCodes[status]; ok {
+		return code
+	}
+	if status >= 400 && status < 500 {
+		return "client_error"
+	}
+	return "internal"
+}
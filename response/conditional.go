@@ -0,0 +1,57 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// IfMatchPolicy decides how CheckIfMatch treats requests without an If-Match header.
+type IfMatchPolicy int
+
+const (
+	// IfMatchOptional lets requests without an If-Match header through.
+	IfMatchOptional IfMatchPolicy = iota
+	// IfMatchRequired rejects requests without an If-Match header.
+	IfMatchRequired
+)
+
+// ErrPreconditionFailed is the error reported by RespondWithPreconditionFailed.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+var ifMatchPolicy = IfMatchOptional
+
+// SetIfMatchPolicy sets how CheckIfMatch handles a missing If-Match header.
+func SetIfMatchPolicy(policy IfMatchPolicy) {
+	ifMatchPolicy = policy
+}
+
+// CheckIfMatch reports whether the request's If-Match header matches currentETag.
+// Matching uses strong comparison, so weak validators never match; "*" matches any ETag.
+func CheckIfMatch(r *http.Request, currentETag string) bool {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return ifMatchPolicy == IfMatchOptional
+	}
+	if header == "*" {
+		return currentETag != ""
+	}
+
+	current := strings.Trim(currentETag, `"`)
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if strings.HasPrefix(candidate, "W/") {
+			continue
+		}
+		if strings.Trim(candidate, `"`) == current {
+			return true
+		}
+	}
+	return false
+}
+
+// RespondWithPreconditionFailed sends a standardized 412 Precondition Failed response.
+func RespondWithPreconditionFailed(ctx context.Context, w http.ResponseWriter) error {
+	return RespondWithError(ctx, w, http.StatusPreconditionFailed, "resource has been modified", ErrPreconditionFailed, "")
+}
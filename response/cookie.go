@@ -0,0 +1,35 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/Ehsan-Eghbali/common/utils"
+)
+
+// CookieOptions holds the attributes of a cookie set by SetSignedCookie.
+type CookieOptions struct {
+	Path     string
+	MaxAge   int
+	HttpOnly bool
+	Secure   bool
+	SameSite http.SameSite
+}
+
+// SetSignedCookie sets a cookie whose value is HMAC-signed with key; read it back with utils.ReadSignedCookie.
+// The value is signed, not encrypted, so it remains readable by the client.
+func SetSignedCookie(w http.ResponseWriter, name, value string, opts CookieOptions, key []byte) {
+	path := opts.Path
+	if path == "" {
+		path = "/"
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    utils.SignCookieValue(name, value, key),
+		Path:     path,
+		MaxAge:   opts.MaxAge,
+		HttpOnly: opts.HttpOnly,
+		Secure:   opts.Secure,
+		SameSite: opts.SameSite,
+	})
+}
@@ -0,0 +1,139 @@
+package response
+
+import (
+	"context"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// errCSVRows is reported when RespondWithCSV is given something other than a slice of structs.
+var errCSVRows = errors.New("csv rows must be a slice of structs")
+
+// csvColumn is one exported struct field written as a CSV column.
+type csvColumn struct {
+	header string
+	index  int
+}
+
+// RespondWithCSV streams rows, a slice of structs or struct pointers, as a CSV download named filename.
+// Column headers come from `csv` struct tags and default to the field names; fields tagged csv:"-" are skipped.
+// A nil or empty slice produces just the header row. Other values are answered with a 500 error.
+// Text cells that a spreadsheet would evaluate as a formula are prefixed with a single quote.
+func RespondWithCSV(ctx context.Context, w http.ResponseWriter, filename string, rows interface{}) error {
+	value := reflect.ValueOf(rows)
+	if !value.IsValid() || value.Kind() != reflect.Slice {
+		return RespondWithError(ctx, w, http.StatusInternalServerError, "failed to encode response", errCSVRows, "")
+	}
+
+	elemType := value.Type().Elem()
+	if elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return RespondWithError(ctx, w, http.StatusInternalServerError, "failed to encode response", errCSVRows, "")
+	}
+	columns := csvColumns(elemType)
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", contentDisposition(filename))
+	writeHeader(w, http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+	}
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for i := 0; i < value.Len(); i++ {
+		row := value.Index(i)
+		if row.Kind() == reflect.Pointer {
+			if row.IsNil() {
+				continue
+			}
+			row = row.Elem()
+		}
+
+		for j, column := range columns {
+			record[j] = csvCell(row.Field(column.index))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvColumns lists the exported fields of t that become CSV columns, in declaration order.
+func csvColumns(t reflect.Type) []csvColumn {
+	columns := make([]csvColumn, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("csv"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, csvColumn{header: name, index: i})
+	}
+	return columns
+}
+
+// csvCell formats a field value as a CSV cell; nil pointers become empty cells.
+// Text cells are passed through escapeCSVFormula; numbers and booleans are written as they are.
+func csvCell(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value.Format(time.RFC3339)
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		if err != nil {
+			return ""
+		}
+		return escapeCSVFormula(string(text))
+	case fmt.Stringer:
+		return escapeCSVFormula(value.String())
+	}
+
+	switch v.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v.Interface())
+	}
+	return escapeCSVFormula(fmt.Sprint(v.Interface()))
+}
+
+// escapeCSVFormula prefixes cells that spreadsheet applications would evaluate as a formula
+// (starting with =, +, -, @, a tab or a carriage return) with a single quote, so user-supplied
+// text such as "=HYPERLINK(...)" is displayed instead of executed.
+func escapeCSVFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRespondWithCSVEscapesFormulas(t *testing.T) {
+	type row struct {
+		Name    string
+		Comment string
+		Balance int
+	}
+	rows := []row{
+		{Name: "=HYPERLINK(\"http://evil.example\")", Comment: "+1", Balance: -5},
+		{Name: "@SUM(A1)", Comment: "-2", Balance: 3},
+		{Name: "\tcmd", Comment: "plain", Balance: 0},
+	}
+
+	w := httptest.NewRecorder()
+	if err := RespondWithCSV(context.Background(), w, "export.csv", rows); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"Name", "Comment", "Balance"},
+		{"'=HYPERLINK(\"http://evil.example\")", "'+1", "-5"},
+		{"'@SUM(A1)", "'-2", "3"},
+		{"'\tcmd", "plain", "0"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(records), len(want))
+	}
+	for i := range want {
+		for j := range want[i] {
+			if records[i][j] != want[i][j] {
+				t.Errorf("record %d column %d = %q, want %q", i, j, records[i][j], want[i][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package response
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+const (
+	// deprecationLogInterval is the minimum time between two usage warnings of one deprecated route.
+	deprecationLogInterval = time.Hour
+	// maxDeprecationClients bounds the clients remembered per interval, since user agents are client-controlled.
+	maxDeprecationClients = 100
+)
+
+// deprecationUsage collects the clients that called a deprecated route since the last warning.
+type deprecationUsage struct {
+	mu         sync.Mutex
+	clients    map[string]int
+	paths      map[string]struct{}
+	lastLogged time.Time
+}
+
+// DeprecationMiddleware marks the wrapped routes as deprecated: every response carries a Deprecation header,
+// a Sunset header (RFC 8594) with the removal date and, when link is not empty, a Link header to the migration docs.
+// At most once an hour it logs a warning listing the paths used and the clients, by user agent, still calling them.
+func DeprecationMiddleware(sunset time.Time, link string) func(http.Handler) http.Handler {
+	usage := &deprecationUsage{
+		clients:    make(map[string]int),
+		paths:      make(map[string]struct{}),
+		lastLogged: time.Now(),
+	}
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Sunset", sunsetHeader)
+			if link != "" {
+				w.Header().Add("Link", "<"+link+`>; rel="deprecation"`)
+			}
+
+			usage.record(r, sunsetHeader)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// record counts the request's client and logs the collected usage once the interval has passed.
+func (u *deprecationUsage) record(r *http.Request, sunset string) {
+	client := r.UserAgent()
+	if client == "" {
+		client = "unknown"
+	}
+
+	u.mu.Lock()
+	if _, known := u.clients[client]; known || len(u.clients) < maxDeprecationClients {
+		u.clients[client]++
+	}
+	u.paths[r.URL.Path] = struct{}{}
+
+	if time.Since(u.lastLogged) < deprecationLogInterval {
+		u.mu.Unlock()
+		return
+	}
+	clients, paths := u.clients, u.paths
+	u.clients = make(map[string]int)
+	u.paths = make(map[string]struct{})
+	u.lastLogged = time.Now()
+	u.mu.Unlock()
+
+	pathList := make([]string, 0, len(paths))
+	for path := range paths {
+		pathList = append(pathList, path)
+	}
+	sort.Strings(pathList)
+
+	logutil.LogWarning(TraceIDFromContext(r.Context()), "deprecated_route_used", map[string]interface{}{
+		"paths":   pathList,
+		"clients": clients,
+		"sunset":  sunset,
+	})
+}
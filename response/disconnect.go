@@ -0,0 +1,31 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// IsClientDisconnect reports whether err, as returned by a Respond helper, means the client went away
+// mid-response (broken pipe, connection reset, canceled request) rather than a server-side failure.
+// Callers can log such errors at debug level instead of alerting on them.
+func IsClientDisconnect(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, net.ErrClosed) {
+		return true
+	}
+
+	// Some writers wrap the syscall error without Unwrap support.
+	message := err.Error()
+	return strings.Contains(message, "broken pipe") ||
+		strings.Contains(message, "connection reset by peer") ||
+		strings.Contains(message, "client disconnected")
+}
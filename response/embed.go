@@ -0,0 +1,77 @@
+package response
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ServeEmbeddedJSON serves a static JSON file from fsys, typically an embed.FS.
+// The file is read and hashed into an ETag on the first request that loads it successfully; a failed
+// load is answered with 500 and retried on the next request. Clients revalidate with If-None-Match
+// and receive 304 when their copy is current.
+func ServeEmbeddedJSON(fsys fs.FS, path string) http.HandlerFunc {
+	var (
+		mu      sync.Mutex
+		loaded  bool
+		content []byte
+		etag    string
+	)
+	load := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if loaded {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		content, etag, loaded = data, `"`+hex.EncodeToString(sum[:16])+`"`, true
+		return nil
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := load(); err != nil {
+			_ = RespondWithError(r.Context(), w, http.StatusInternalServerError, "failed to load static content", err, "")
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", "no-cache")
+
+		if etagMatches(r.Header.Get("If-None-Match"), etag) {
+			writeHeader(w, http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		writeHeader(w, http.StatusOK)
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(content)
+		}
+	}
+}
+
+// etagMatches reports whether an If-None-Match header matches etag using weak comparison.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
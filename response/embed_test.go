@@ -0,0 +1,29 @@
+package response
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestServeEmbeddedJSONRetriesFailedLoad(t *testing.T) {
+	SetExposeInternalErrors(true)
+	t.Cleanup(func() { exposeInternalErrors.Store(0) })
+
+	fsys := fstest.MapFS{}
+	handler := ServeEmbeddedJSON(fsys, "config.json")
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/config", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("got status %d for a missing file, want 500", w.Code)
+	}
+
+	fsys["config.json"] = &fstest.MapFile{Data: []byte(`{"ok":true}`)}
+	w = httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/config", nil))
+	if w.Code != http.StatusOK || w.Body.String() != `{"ok":true}` {
+		t.Fatalf("got status %d body %q after the file appeared, want 200 with the file", w.Code, w.Body.String())
+	}
+}
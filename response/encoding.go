@@ -0,0 +1,55 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+)
+
+// maxSafeInteger is the largest integer a JavaScript client can parse without losing precision (2^53 - 1).
+var maxSafeInteger = big.NewInt(1<<53 - 1)
+
+var largeIntAsString bool
+
+// SetLargeIntAsString makes RespondWithSuccess encode integers outside ±(2^53 - 1) as JSON strings,
+// so JavaScript clients do not silently round large IDs. Smaller integers stay numbers.
+func SetLargeIntAsString(enabled bool) {
+	largeIntAsString = enabled
+}
+
+// toGeneric round-trips data through JSON into maps, slices and json.Number values, keeping numbers exact.
+func toGeneric(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// stringifyLargeInts replaces integers that JavaScript cannot represent exactly with their string form.
+func stringifyLargeInts(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = stringifyLargeInts(nested)
+		}
+	case []interface{}:
+		for i, nested := range v {
+			v[i] = stringifyLargeInts(nested)
+		}
+	case json.Number:
+		n, ok := new(big.Int).SetString(v.String(), 10)
+		if ok && n.CmpAbs(maxSafeInteger) > 0 {
+			return v.String()
+		}
+	}
+	return value
+}
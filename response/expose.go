@@ -0,0 +1,36 @@
+package response
+
+import (
+	"sync/atomic"
+
+	"github.com/Ehsan-Eghbali/common/utils"
+)
+
+// suppressedReason replaces the Reason of 5xx responses when internal errors are hidden.
+const suppressedReason = "internal server error"
+
+// exposeInternalErrors is 0 until SetExposeInternalErrors is called, then 1 (hide) or 2 (expose).
+var exposeInternalErrors atomic.Int32
+
+// SetExposeInternalErrors controls whether 5xx error responses carry err.Error() as their Reason.
+// When hidden, the real reason is logged with the trace ID instead. 4xx reasons are always shown.
+// Until this is called, internal errors are exposed everywhere except production.
+func SetExposeInternalErrors(expose bool) {
+	if expose {
+		exposeInternalErrors.Store(2)
+	} else {
+		exposeInternalErrors.Store(1)
+	}
+}
+
+// exposesInternalErrors reports whether 5xx reasons may be sent to clients.
+func exposesInternalErrors() bool {
+	switch exposeInternalErrors.Load() {
+	case 1:
+		return false
+	case 2:
+		return true
+	default:
+		return !utils.IsProduction()
+	}
+}
@@ -0,0 +1,101 @@
+package response
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Ehsan-Eghbali/common/utils"
+)
+
+// sniffLen is the number of bytes http.DetectContentType inspects.
+const sniffLen = 512
+
+// RespondWithFile streams r to the client as a download named filename.
+// Content-Length is set when the size of r can be determined without reading it.
+// An empty contentType is detected from filename and the start of r, see utils.DetectContentType.
+func RespondWithFile(ctx context.Context, w http.ResponseWriter, filename string, contentType string, r io.Reader) error {
+	size, sized := readerSize(r)
+
+	if contentType == "" {
+		buffered := bufio.NewReaderSize(r, sniffLen)
+		head, _ := buffered.Peek(sniffLen)
+		contentType = utils.DetectContentType(head, filename)
+		r = buffered
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", contentDisposition(filename))
+	if sized {
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	}
+	writeHeader(w, http.StatusOK)
+
+	_, err := io.Copy(w, r)
+	return err
+}
+
+// contentDisposition builds an attachment header with an ASCII fallback name
+// and, for non-ASCII names, an RFC 5987 encoded filename* parameter.
+func contentDisposition(filename string) string {
+	var fallback strings.Builder
+	ascii := true
+	for _, c := range filename {
+		switch {
+		case c > 0x7e || c < 0x20:
+			ascii = false
+			fallback.WriteByte('_')
+		case c == '"' || c == '\\':
+			fallback.WriteByte('\\')
+			fallback.WriteRune(c)
+		default:
+			fallback.WriteRune(c)
+		}
+	}
+
+	header := fmt.Sprintf(`attachment; filename="%s"`, fallback.String())
+	if !ascii {
+		header += "; filename*=UTF-8''" + encodeRFC5987(filename)
+	}
+	return header
+}
+
+// encodeRFC5987 percent-encodes every byte of s that is not an RFC 5987 attr-char.
+func encodeRFC5987(s string) string {
+	const attrChars = "!#$&+-.^_`|~"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || strings.IndexByte(attrChars, c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+// readerSize returns the number of bytes left in r when it can be known up front.
+func readerSize(r io.Reader) (int64, bool) {
+	switch v := r.(type) {
+	case interface{ Len() int }:
+		return int64(v.Len()), true
+	case *os.File:
+		info, err := v.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0, false
+		}
+		offset, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, false
+		}
+		return info.Size() - offset, true
+	}
+	return 0, false
+}
@@ -0,0 +1,41 @@
+// Package grpcweb writes gRPC-Web style statuses from plain HTTP handlers.
+// It is kept apart from package response so REST-only users do not see it.
+package grpcweb
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Ehsan-Eghbali/common/response"
+)
+
+// ContentType is the content type of gRPC-Web responses carrying protobuf messages.
+const ContentType = "application/grpc-web+proto"
+
+// RespondWithGRPCWebStatus ends a gRPC-Web call with the given gRPC status code and message in the
+// grpc-status and grpc-message headers and an empty body. As gRPC-Web requires, the HTTP status is
+// always 200; the message is percent-encoded as the gRPC protocol specifies.
+func RespondWithGRPCWebStatus(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("grpc-status", strconv.Itoa(code))
+	if message != "" {
+		w.Header().Set("grpc-message", encodeGRPCMessage(message))
+	}
+	response.WriteHeader(w, http.StatusOK)
+}
+
+// encodeGRPCMessage percent-encodes every byte of message outside printable ASCII, and '%' itself.
+func encodeGRPCMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
@@ -0,0 +1,35 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+// HandlerFunc adapts a handler that returns an error into an http.HandlerFunc.
+// A non-nil error is logged once and turned into an error response: errors registered with RegisterError
+// use their code's status and message, anything else becomes a 500.
+func HandlerFunc(fn func(w http.ResponseWriter, r *http.Request) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := fn(w, r)
+		if err == nil {
+			return
+		}
+
+		ctx := r.Context()
+		traceID := TraceIDFromContext(ctx)
+		code, ok := ErrorCodeFor(err)
+		if !ok {
+			code = ErrorCodeForStatus(http.StatusInternalServerError)
+		}
+
+		logutil.LogError(traceID, "http_handler_error", err, map[string]interface{}{
+			"method": r.Method,
+			"path":   r.URL.Path,
+			"code":   code,
+		})
+
+		entry, _ := lookupErrorCode(code)
+		_ = encodeError(w, entry.Status, code, entry.Message, err, traceID)
+	}
+}
@@ -0,0 +1,58 @@
+package response
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// errorRecorder is a hook that keeps the event of every error entry fired while it is installed
+type errorRecorder struct {
+	mu     sync.Mutex
+	events []interface{}
+}
+
+func (r *errorRecorder) Levels() []logrus.Level {
+	return []logrus.Level{logrus.ErrorLevel}
+}
+
+func (r *errorRecorder) Fire(entry *logrus.Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, entry.Data["event"])
+	return nil
+}
+
+func TestHandlerFuncLogsInternalErrorsOnce(t *testing.T) {
+	logger := logrus.StandardLogger()
+	out, hooks := logger.Out, logger.ReplaceHooks(make(logrus.LevelHooks))
+	rec := &errorRecorder{}
+	logger.AddHook(rec)
+	logger.SetOutput(io.Discard)
+	t.Cleanup(func() {
+		logger.ReplaceHooks(hooks)
+		logger.SetOutput(out)
+	})
+
+	SetExposeInternalErrors(false)
+	t.Cleanup(func() { exposeInternalErrors.Store(0) })
+
+	handler := HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("database unreachable")
+	})
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("got status %d, want 500", w.Code)
+	}
+	if len(rec.events) != 1 {
+		t.Fatalf("got error events %v, want exactly one", rec.events)
+	}
+}
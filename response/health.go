@@ -0,0 +1,68 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// HealthStatus is the outcome of a single health check or of all checks combined.
+type HealthStatus string
+
+const (
+	HealthPass HealthStatus = "pass"
+	HealthWarn HealthStatus = "warn"
+	HealthFail HealthStatus = "fail"
+)
+
+// HealthCheck is the result of checking one dependency.
+type HealthCheck struct {
+	Status  HealthStatus
+	Message string
+	Latency time.Duration
+}
+
+// healthCheckBody is the JSON shape of a HealthCheck.
+type healthCheckBody struct {
+	Status    HealthStatus `json:"status"`
+	Message   string       `json:"message,omitempty"`
+	LatencyMs float64      `json:"latency_ms"`
+}
+
+// healthBody is the JSON shape of a health response.
+type healthBody struct {
+	Status HealthStatus               `json:"status"`
+	Checks map[string]healthCheckBody `json:"checks"`
+}
+
+// RespondWithHealth sends a standardized health response with an aggregate status.
+// It responds 503 when any check fails and 200 otherwise; warnings do not fail the aggregate.
+func RespondWithHealth(ctx context.Context, w http.ResponseWriter, checks map[string]HealthCheck) error {
+	body := healthBody{
+		Status: HealthPass,
+		Checks: make(map[string]healthCheckBody, len(checks)),
+	}
+
+	for name, check := range checks {
+		switch check.Status {
+		case HealthFail:
+			body.Status = HealthFail
+		case HealthWarn:
+			if body.Status == HealthPass {
+				body.Status = HealthWarn
+			}
+		}
+
+		body.Checks[name] = healthCheckBody{
+			Status:    check.Status,
+			Message:   check.Message,
+			LatencyMs: float64(check.Latency) / float64(time.Millisecond),
+		}
+	}
+
+	statusCode := http.StatusOK
+	if body.Status == HealthFail {
+		statusCode = http.StatusServiceUnavailable
+	}
+	return RespondWithSuccess(ctx, w, statusCode, body)
+}
@@ -0,0 +1,42 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// JSONAPIContentType is the media type defined by the JSON:API specification.
+const JSONAPIContentType = "application/vnd.api+json"
+
+// Document is a JSON:API top-level document. Data holds a ResourceObject, a slice of them or nil;
+// a document must not contain both Data and Errors.
+type Document struct {
+	Data   interface{}            `json:"data,omitempty"`
+	Errors []ErrorObject          `json:"errors,omitempty"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+	Links  map[string]string      `json:"links,omitempty"`
+}
+
+// ResourceObject is a JSON:API resource.
+type ResourceObject struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ErrorObject is a JSON:API error.
+type ErrorObject struct {
+	Status string `json:"status,omitempty"`
+	Code   string `json:"code,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// RespondWithJSONAPI sends doc as a JSON:API document. It is independent of the default envelope used by the other responders.
+func RespondWithJSONAPI(ctx context.Context, w http.ResponseWriter, statusCode int, doc Document) error {
+	w.Header().Set("Content-Type", JSONAPIContentType)
+	writeHeader(w, statusCode)
+
+	return json.NewEncoder(w).Encode(doc)
+}
@@ -0,0 +1,62 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// JSONArrayWriter streams values as the elements of a single JSON array, for clients that cannot read NDJSON.
+type JSONArrayWriter struct {
+	stream *streamBase
+	count  int
+}
+
+// NewJSONArrayWriter writes the JSON headers and status code and returns a writer for the array elements.
+// ctx should be the request context: once it is done, Write returns ErrStreamClosed without writing.
+func NewJSONArrayWriter(ctx context.Context, w http.ResponseWriter, statusCode int) *JSONArrayWriter {
+	return &JSONArrayWriter{stream: newStreamBase(ctx, w, "application/json", statusCode)}
+}
+
+// Write encodes v as the next array element, opening the array on the first call.
+// It returns ErrStreamClosed once the client has disconnected; stop producing elements then.
+func (aw *JSONArrayWriter) Write(v interface{}) error {
+	element, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	separator := byte(',')
+	if aw.count == 0 {
+		separator = '['
+	}
+	if err := aw.stream.write(append([]byte{separator}, element...)); err != nil {
+		return err
+	}
+	aw.count++
+	return nil
+}
+
+// Flush sends any buffered elements to the client immediately.
+func (aw *JSONArrayWriter) Flush() {
+	aw.stream.flush()
+}
+
+// Close terminates the array and flushes it. The closing bracket is skipped, and ErrStreamClosed
+// returned, when the client is already gone. Close must be called exactly once, even if nothing was written.
+func (aw *JSONArrayWriter) Close() error {
+	closing := "]"
+	if aw.count == 0 {
+		closing = "[]"
+	}
+	if err := aw.stream.write([]byte(closing)); err != nil {
+		aw.stream.close()
+		return err
+	}
+
+	aw.stream.flush()
+	if !aw.stream.close() {
+		return ErrStreamClosed
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// LongPoll blocks until wait returns or timeout elapses. It responds 200 with the data wait produced,
+// 204 when the timeout elapses first, and 500 when wait fails. ctx should be the request context:
+// if the client disconnects nothing is written and the context error is returned.
+func LongPoll(ctx context.Context, w http.ResponseWriter, timeout time.Duration, wait func(context.Context) (interface{}, error)) error {
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		data interface{}
+		err  error
+	}
+	results := make(chan result, 1)
+	go func() {
+		data, err := wait(pollCtx)
+		results <- result{data: data, err: err}
+	}()
+
+	select {
+	case res := <-results:
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if res.err != nil {
+			if pollCtx.Err() != nil {
+				writeHeader(w, http.StatusNoContent)
+				return nil
+			}
+			return RespondWithError(ctx, w, http.StatusInternalServerError, "long poll failed", res.err, "")
+		}
+		return RespondWithSuccess(ctx, w, http.StatusOK, res.data)
+	case <-pollCtx.Done():
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		writeHeader(w, http.StatusNoContent)
+		return nil
+	}
+}
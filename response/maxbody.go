@@ -0,0 +1,119 @@
+package response
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+// ErrRequestTooLarge is the error reported when a request body exceeds the MaxBodySizeMiddleware limit.
+var ErrRequestTooLarge = errors.New("request body too large")
+
+// MaxBodySizeMiddleware limits request bodies to maxBytes with http.MaxBytesReader and answers oversized
+// requests with a 413 error envelope. Requests declaring a larger Content-Length are rejected up front;
+// otherwise, once a handler's read trips the limit, whatever the handler tries to write is replaced by the 413.
+// Each rejection is logged with the path and the attempted size.
+func MaxBodySizeMiddleware(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				rejectLargeBody(w, r, maxBytes)
+				return
+			}
+			if r.Body == nil || r.Body == http.NoBody {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			bw := &bodyLimitWriter{ResponseWriter: w, r: r, maxBytes: maxBytes}
+			r.Body = &bodyLimitReader{ReadCloser: http.MaxBytesReader(w, r.Body, maxBytes), tripped: &bw.tripped}
+			next.ServeHTTP(bw, r)
+
+			if bw.tripped.Load() && !bw.wroteHeader {
+				bw.reject()
+			}
+		})
+	}
+}
+
+// rejectLargeBody logs the oversized request and responds 413.
+func rejectLargeBody(w http.ResponseWriter, r *http.Request, maxBytes int64) {
+	fields := map[string]interface{}{
+		"method":    r.Method,
+		"path":      r.URL.Path,
+		"max_bytes": maxBytes,
+	}
+	if r.ContentLength >= 0 {
+		fields["content_length"] = r.ContentLength
+	}
+	logutil.LogWarning(TraceIDFromContext(r.Context()), "request_body_too_large", fields)
+
+	w.Header().Del("Content-Length")
+	_ = RespondWithError(r.Context(), w, http.StatusRequestEntityTooLarge, "request body too large", ErrRequestTooLarge, "")
+}
+
+// bodyLimitReader records when the wrapped http.MaxBytesReader refuses to read past the limit.
+type bodyLimitReader struct {
+	io.ReadCloser
+	tripped *atomic.Bool
+}
+
+// Read reads from the limited body, flagging the request once the limit is hit.
+func (b *bodyLimitReader) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		b.tripped.Store(true)
+	}
+	return n, err
+}
+
+// bodyLimitWriter replaces the handler's response with a 413 once its request body tripped the limit.
+type bodyLimitWriter struct {
+	http.ResponseWriter
+	r        *http.Request
+	maxBytes int64
+	tripped  atomic.Bool
+
+	wroteHeader bool
+	rejected    bool
+}
+
+// reject writes the 413 response in place of the handler's.
+func (b *bodyLimitWriter) reject() {
+	b.wroteHeader = true
+	b.rejected = true
+	rejectLargeBody(b.ResponseWriter, b.r, b.maxBytes)
+}
+
+// WriteHeader forwards the status unless the body limit was tripped, in which case the 413 is sent instead.
+func (b *bodyLimitWriter) WriteHeader(statusCode int) {
+	if b.wroteHeader {
+		return
+	}
+	if b.tripped.Load() {
+		b.reject()
+		return
+	}
+	b.wroteHeader = true
+	b.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write forwards p unless the response was replaced by the 413, in which case it is discarded.
+func (b *bodyLimitWriter) Write(p []byte) (int, error) {
+	if !b.wroteHeader {
+		b.WriteHeader(http.StatusOK)
+	}
+	if b.rejected {
+		return len(p), nil
+	}
+	return b.ResponseWriter.Write(p)
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (b *bodyLimitWriter) Unwrap() http.ResponseWriter {
+	return b.ResponseWriter
+}
@@ -0,0 +1,67 @@
+package response
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+// partialError describes a section of a merged response that could not be produced.
+type partialError struct {
+	ErrorCode string `json:"error_code"`
+	Reason    string `json:"reason"`
+}
+
+// mergedBody is the JSON shape of a merged response.
+type mergedBody struct {
+	Data          map[string]interface{}  `json:"data"`
+	PartialErrors map[string]partialError `json:"partial_errors,omitempty"`
+}
+
+// RespondWithMerged sends several independently produced sections in one envelope:
+// {"data": {"section": ...}, "partial_errors": {"section": {...}}}.
+// A section whose value is an error is reported under partial_errors instead of data, and the
+// response is still written with statusCode; pass http.StatusMultiStatus to signal partial failure.
+// Error reasons follow the same exposure rules as 5xx errors, see SetExposeInternalErrors.
+func RespondWithMerged(ctx context.Context, w http.ResponseWriter, statusCode int, sections map[string]interface{}) error {
+	body := mergedBody{
+		Data: make(map[string]interface{}, len(sections)),
+	}
+
+	for name, section := range sections {
+		err, ok := section.(error)
+		if !ok {
+			body.Data[name] = section
+			continue
+		}
+
+		if body.PartialErrors == nil {
+			body.PartialErrors = make(map[string]partialError)
+		}
+		body.PartialErrors[name] = newPartialError(ctx, name, err)
+	}
+
+	return RespondWithSuccess(ctx, w, statusCode, body)
+}
+
+// newPartialError builds the partial_errors entry for a failed section.
+func newPartialError(ctx context.Context, section string, err error) partialError {
+	code, ok := ErrorCodeFor(err)
+	if !ok {
+		code = ErrorCodeForStatus(http.StatusInternalServerError)
+	}
+
+	reason := err.Error()
+	if !exposesInternalErrors() {
+		logutil.LogError(resolveTraceID(ctx, ""), "partial_error_suppressed", err, map[string]interface{}{
+			"section": section,
+		})
+		reason = suppressedReason
+	}
+
+	return partialError{
+		ErrorCode: code,
+		Reason:    reason,
+	}
+}
@@ -0,0 +1,60 @@
+package response
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+var (
+	statusMetricsEnabled atomic.Bool
+	// statusCounters holds the response counts for the 1xx to 5xx classes at indexes 0 to 4.
+	statusCounters [5]atomic.Int64
+)
+
+// EnableStatusMetrics starts counting the responses written by the Respond helpers by status class.
+func EnableStatusMetrics() {
+	statusMetricsEnabled.Store(true)
+}
+
+// StatusCounts returns a snapshot of the response counts keyed by status class ("2xx", "4xx", ...).
+func StatusCounts() map[string]int64 {
+	counts := make(map[string]int64, len(statusCounters))
+	for i := range statusCounters {
+		counts[string(rune('1'+i))+"xx"] = statusCounters[i].Load()
+	}
+	return counts
+}
+
+// WriteHeader writes statusCode like w.WriteHeader, counting it when status metrics are enabled.
+// It is meant for responders built outside this package, such as response/grpcweb.
+func WriteHeader(w http.ResponseWriter, statusCode int) {
+	writeHeader(w, statusCode)
+}
+
+// writeHeader writes the status code, counting it when status metrics are enabled.
+// Every responder in this package writes its status through here.
+func writeHeader(w http.ResponseWriter, statusCode int) {
+	if statusMetricsEnabled.Load() {
+		if class := statusCode/100 - 1; class >= 0 && class < len(statusCounters) {
+			statusCounters[class].Add(1)
+		}
+	}
+	w.WriteHeader(statusCode)
+}
+
+// largeResponseThreshold is the body size in bytes above which the logging middleware warns; 0 disables the warning.
+var largeResponseThreshold atomic.Int64
+
+// SetLargeResponseThreshold makes the logging middleware (logutil/middleware) log a warning for every
+// response body larger than n bytes, to catch accidental full-table dumps. Zero or less disables it.
+func SetLargeResponseThreshold(n int) {
+	if n < 0 {
+		n = 0
+	}
+	largeResponseThreshold.Store(int64(n))
+}
+
+// LargeResponseThreshold returns the threshold set by SetLargeResponseThreshold, 0 when disabled.
+func LargeResponseThreshold() int {
+	return int(largeResponseThreshold.Load())
+}
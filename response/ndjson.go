@@ -0,0 +1,49 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// NDJSONWriter streams values as newline-delimited JSON (application/x-ndjson).
+type NDJSONWriter struct {
+	stream *streamBase
+}
+
+// NewNDJSONWriter writes the NDJSON headers and status code and returns a writer for the records.
+// A disconnected client is only noticed when a write fails; use NewNDJSONWriterContext to stop as soon as
+// the request context is done.
+func NewNDJSONWriter(w http.ResponseWriter, statusCode int) *NDJSONWriter {
+	return NewNDJSONWriterContext(context.Background(), w, statusCode)
+}
+
+// NewNDJSONWriterContext is like NewNDJSONWriter but watches ctx, which should be the request context:
+// once it is done, Write returns ErrStreamClosed without writing.
+func NewNDJSONWriterContext(ctx context.Context, w http.ResponseWriter, statusCode int) *NDJSONWriter {
+	return &NDJSONWriter{stream: newStreamBase(ctx, w, "application/x-ndjson", statusCode)}
+}
+
+// Write encodes v as a single line. Output is flushed to the client periodically when the writer supports it.
+// It returns ErrStreamClosed once the client has disconnected; stop producing records then.
+func (nw *NDJSONWriter) Write(v interface{}) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return nw.stream.write(append(line, '\n'))
+}
+
+// Flush sends any buffered records to the client immediately.
+func (nw *NDJSONWriter) Flush() {
+	nw.stream.flush()
+}
+
+// Close flushes the remaining records and stops watching the request context.
+func (nw *NDJSONWriter) Close() error {
+	nw.stream.flush()
+	if !nw.stream.close() {
+		return ErrStreamClosed
+	}
+	return nil
+}
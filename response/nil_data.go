@@ -0,0 +1,38 @@
+package response
+
+import (
+	"reflect"
+)
+
+// NilDataBehavior controls what RespondWithSuccess writes when data is nil.
+type NilDataBehavior int
+
+const (
+	// EmptyBody writes no body at all.
+	EmptyBody NilDataBehavior = iota
+	// EmptyObject writes {}.
+	EmptyObject
+	// Null writes the JSON literal null.
+	Null
+)
+
+var nilDataBehavior = EmptyBody
+
+// SetNilDataBehavior sets what RespondWithSuccess writes for nil data with a 2xx status.
+func SetNilDataBehavior(mode NilDataBehavior) {
+	nilDataBehavior = mode
+}
+
+// isNilData reports whether data is nil or a nil pointer, map, slice or interface.
+func isNilData(data interface{}) bool {
+	if data == nil {
+		return true
+	}
+
+	v := reflect.ValueOf(data)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
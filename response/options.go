@@ -0,0 +1,18 @@
+package response
+
+// Option customizes how a single response is written.
+type Option func(*options)
+
+// options holds the per-response settings collected from Option values.
+type options struct {
+	redactFields map[string]struct{}
+}
+
+// newOptions applies opts on top of the default options.
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -0,0 +1,66 @@
+package response
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Return preferences from the Prefer header (RFC 7240).
+const (
+	ReturnRepresentation = "representation"
+	ReturnMinimal        = "minimal"
+)
+
+// PreferOptions holds the preferences a client sent in its Prefer headers.
+type PreferOptions struct {
+	// Return is ReturnMinimal or ReturnRepresentation; it defaults to ReturnRepresentation.
+	Return string
+	// Preferences holds every preference by lower-cased name, with an empty value for bare tokens.
+	Preferences map[string]string
+}
+
+// ParsePrefer parses the request's Prefer headers.
+func ParsePrefer(r *http.Request) PreferOptions {
+	opts := PreferOptions{
+		Return:      ReturnRepresentation,
+		Preferences: make(map[string]string),
+	}
+
+	for _, header := range r.Header.Values("Prefer") {
+		for _, pref := range strings.Split(header, ",") {
+			// Parameters after ';' refine a preference; none of the ones we honor use them.
+			pref, _, _ = strings.Cut(pref, ";")
+			name, value, _ := strings.Cut(strings.TrimSpace(pref), "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			opts.Preferences[name] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+
+	if strings.EqualFold(opts.Preferences["return"], ReturnMinimal) {
+		opts.Return = ReturnMinimal
+	}
+	return opts
+}
+
+// RespondWithCreated sends a 201 Created response with a Location header.
+// When the client sent Prefer: return=minimal the body is omitted; otherwise data is returned in full.
+func RespondWithCreated(ctx context.Context, w http.ResponseWriter, r *http.Request, location string, data interface{}) error {
+	if location != "" {
+		w.Header().Set("Location", location)
+	}
+
+	prefer := ParsePrefer(r)
+	if _, ok := prefer.Preferences["return"]; ok {
+		w.Header().Set("Preference-Applied", "return="+prefer.Return)
+	}
+
+	if prefer.Return == ReturnMinimal {
+		writeHeader(w, http.StatusCreated)
+		return nil
+	}
+	return RespondWithSuccess(ctx, w, http.StatusCreated, data)
+}
@@ -0,0 +1,71 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem documents.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document. ErrorCode and TraceID are extension members
+// carrying the same values as the default error envelope.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+	TraceID   string `json:"trace_id,omitempty"`
+}
+
+// RespondWithProblem sends p as application/problem+json. An empty Type defaults to "about:blank"
+// and a missing Status to 500.
+func RespondWithProblem(ctx context.Context, w http.ResponseWriter, p Problem) error {
+	if p.Type == "" {
+		p.Type = "about:blank"
+	}
+	if p.Status <= 0 {
+		p.Status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	writeHeader(w, p.Status)
+
+	return json.NewEncoder(w).Encode(p)
+}
+
+// RespondWithProblemFromError builds a problem document from err. Errors registered with RegisterError
+// take their code's status and message as title; anything else becomes a 500. Detail carries the error
+// message, which is hidden for 5xx unless internal errors are exposed, and Instance is the request path.
+func RespondWithProblemFromError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) error {
+	traceID := TraceIDFromContext(ctx)
+
+	code, ok := ErrorCodeFor(err)
+	if !ok {
+		code = ErrorCodeForStatus(http.StatusInternalServerError)
+	}
+	entry, _ := lookupErrorCode(code)
+
+	detail := err.Error()
+	if entry.Status >= 500 && !exposesInternalErrors() {
+		logutil.LogError(traceID, "internal_error_suppressed", err, map[string]interface{}{
+			"code":       code,
+			"statusCode": entry.Status,
+		})
+		detail = suppressedReason
+	}
+
+	return RespondWithProblem(ctx, w, Problem{
+		Title:     entry.Message,
+		Status:    entry.Status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		ErrorCode: code,
+		TraceID:   traceID,
+	})
+}
@@ -0,0 +1,136 @@
+package response
+
+import (
+	"bytes"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetricType is the Prometheus metric type announced in the # TYPE line.
+type MetricType string
+
+const (
+	MetricCounter   MetricType = "counter"
+	MetricGauge     MetricType = "gauge"
+	MetricUntyped   MetricType = "untyped"
+	MetricSummary   MetricType = "summary"
+	MetricHistogram MetricType = "histogram"
+)
+
+// Metric is a single sample. Samples sharing a Name form one metric family;
+// its Help and Type are taken from the first sample. Histogram and summary samples are named
+// after their series, e.g. "latency_bucket", "latency_sum" and "latency_count", and are grouped
+// into the family of the base name ("latency").
+type Metric struct {
+	Name   string
+	Help   string
+	Type   MetricType
+	Labels map[string]string
+	Value  float64
+}
+
+// RespondWithMetrics writes metrics in the Prometheus text exposition format, for a /metrics endpoint.
+// Families keep the order in which their first sample appears and label values are escaped as the format requires.
+func RespondWithMetrics(w http.ResponseWriter, metrics []Metric) error {
+	var order []string
+	families := make(map[string][]Metric)
+	for _, metric := range metrics {
+		family := familyName(metric)
+		if _, ok := families[family]; !ok {
+			order = append(order, family)
+		}
+		families[family] = append(families[family], metric)
+	}
+
+	var buf bytes.Buffer
+	for _, name := range order {
+		samples := families[name]
+		if help := samples[0].Help; help != "" {
+			buf.WriteString("# HELP " + name + " " + escapeHelp(help) + "\n")
+		}
+		metricType := samples[0].Type
+		if metricType == "" {
+			metricType = MetricUntyped
+		}
+		buf.WriteString("# TYPE " + name + " " + string(metricType) + "\n")
+
+		for _, sample := range samples {
+			buf.WriteString(sample.Name)
+			writeLabels(&buf, sample.Labels)
+			buf.WriteString(" " + formatSampleValue(sample.Value) + "\n")
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writeHeader(w, http.StatusOK)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// familyName returns the metric family a sample belongs to: its name, minus the _bucket, _sum or _count
+// series suffix for histogram and summary samples.
+func familyName(metric Metric) string {
+	var suffixes []string
+	switch metric.Type {
+	case MetricHistogram:
+		suffixes = []string{"_bucket", "_sum", "_count"}
+	case MetricSummary:
+		suffixes = []string{"_sum", "_count"}
+	}
+
+	for _, suffix := range suffixes {
+		if base, ok := strings.CutSuffix(metric.Name, suffix); ok && base != "" {
+			return base
+		}
+	}
+	return metric.Name
+}
+
+// writeLabels appends {name="value",...} with names sorted, or nothing for an empty label set.
+func writeLabels(buf *bytes.Buffer, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(name + `="` + escapeLabelValue(labels[name]) + `"`)
+	}
+	buf.WriteByte('}')
+}
+
+// escapeLabelValue escapes backslashes, double quotes and line feeds in a label value.
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// escapeHelp escapes backslashes and line feeds in a HELP docstring.
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// formatSampleValue formats v as the exposition format expects, including NaN and ±Inf.
+func formatSampleValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
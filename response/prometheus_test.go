@@ -0,0 +1,54 @@
+package response
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+func TestRespondWithMetricsGroupsHistogramSeries(t *testing.T) {
+	metrics := []Metric{
+		{Name: "requests_total", Help: "Requests served.", Type: MetricCounter, Value: 7},
+		{Name: "latency_seconds_bucket", Help: "Request latency.", Type: MetricHistogram, Labels: map[string]string{"le": "0.1"}, Value: 3},
+		{Name: "latency_seconds_bucket", Type: MetricHistogram, Labels: map[string]string{"le": "+Inf"}, Value: 5},
+		{Name: "latency_seconds_sum", Type: MetricHistogram, Value: 1.5},
+		{Name: "latency_seconds_count", Type: MetricHistogram, Value: 5},
+		{Name: "size_bytes", Help: "Response size.", Type: MetricSummary, Labels: map[string]string{"quantile": "0.5"}, Value: 512},
+		{Name: "size_bytes_sum", Type: MetricSummary, Value: 2048},
+		{Name: "size_bytes_count", Type: MetricSummary, Value: 4},
+	}
+
+	w := httptest.NewRecorder()
+	if err := RespondWithMetrics(w, metrics); err != nil {
+		t.Fatal(err)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(w.Body)
+	if err != nil {
+		t.Fatalf("output does not parse as the text exposition format: %v", err)
+	}
+	if len(families) != 3 {
+		t.Fatalf("got %d families, want 3", len(families))
+	}
+
+	latency := families["latency_seconds"]
+	if latency == nil || latency.GetType() != dto.MetricType_HISTOGRAM {
+		t.Fatalf("latency_seconds = %v, want a histogram family", latency)
+	}
+	histogram := latency.GetMetric()[0].GetHistogram()
+	if histogram.GetSampleCount() != 5 || histogram.GetSampleSum() != 1.5 || len(histogram.GetBucket()) != 2 {
+		t.Fatalf("latency_seconds histogram = %v, want count 5, sum 1.5 and 2 buckets", histogram)
+	}
+
+	size := families["size_bytes"]
+	if size == nil || size.GetType() != dto.MetricType_SUMMARY {
+		t.Fatalf("size_bytes = %v, want a summary family", size)
+	}
+	summary := size.GetMetric()[0].GetSummary()
+	if summary.GetSampleCount() != 4 || summary.GetSampleSum() != 2048 || len(summary.GetQuantile()) != 1 {
+		t.Fatalf("size_bytes summary = %v, want count 4, sum 2048 and 1 quantile", summary)
+	}
+}
@@ -0,0 +1,98 @@
+package response
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// byteRange is an inclusive range of byte offsets.
+type byteRange struct {
+	start, end int64
+}
+
+// ServeContentRange serves the size bytes readable from at, honoring a Range header for resumable downloads.
+// A satisfiable range is answered with 206 and Content-Range, an unsatisfiable one with 416, and a
+// missing or malformed header with the full content and 200. Multi-range requests get their first range only.
+// Content-Type defaults to application/octet-stream when the caller has not set it.
+func ServeContentRange(w http.ResponseWriter, r *http.Request, size int64, at io.ReaderAt) error {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	w.Header().Set("Accept-Ranges", "bytes")
+
+	rng, satisfiable, ok := parseRange(r.Header.Get("Range"), size)
+	if !ok {
+		rng = byteRange{start: 0, end: size - 1}
+	} else if !satisfiable {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		writeHeader(w, http.StatusRequestedRangeNotSatisfiable)
+		return nil
+	}
+
+	length := rng.end - rng.start + 1
+	w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	if ok {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", rng.start, rng.end, size))
+		writeHeader(w, http.StatusPartialContent)
+	} else {
+		writeHeader(w, http.StatusOK)
+	}
+
+	if r.Method == http.MethodHead || length <= 0 {
+		return nil
+	}
+	_, err := io.Copy(w, io.NewSectionReader(at, rng.start, length))
+	return err
+}
+
+// parseRange returns the first range of a "bytes=" Range header, clamped to size.
+// ok is false when the header is absent or malformed and should be ignored;
+// satisfiable is false when the range lies entirely beyond the content.
+func parseRange(header string, size int64) (rng byteRange, satisfiable bool, ok bool) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found {
+		return byteRange{}, false, false
+	}
+	first, _, _ := strings.Cut(spec, ",")
+	startText, endText, found := strings.Cut(strings.TrimSpace(first), "-")
+	if !found {
+		return byteRange{}, false, false
+	}
+
+	if startText == "" {
+		// Suffix range: the last n bytes.
+		n, err := strconv.ParseInt(endText, 10, 64)
+		if err != nil || n < 0 {
+			return byteRange{}, false, false
+		}
+		if n == 0 || size == 0 {
+			return byteRange{}, false, true
+		}
+		if n > size {
+			n = size
+		}
+		return byteRange{start: size - n, end: size - 1}, true, true
+	}
+
+	start, err := strconv.ParseInt(startText, 10, 64)
+	if err != nil || start < 0 {
+		return byteRange{}, false, false
+	}
+	end := size - 1
+	if endText != "" {
+		end, err = strconv.ParseInt(endText, 10, 64)
+		if err != nil || end < start {
+			return byteRange{}, false, false
+		}
+		if end > size-1 {
+			end = size - 1
+		}
+	}
+	if start >= size {
+		return byteRange{}, false, true
+	}
+	return byteRange{start: start, end: end}, true, true
+}
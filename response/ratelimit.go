@@ -0,0 +1,120 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is the error reported when RateLimitMiddleware rejects a request.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// Limiter decides whether a request identified by key may proceed.
+// When it may not, retryAfter tells the client how long to wait.
+type Limiter interface {
+	Allow(key string) (allowed bool, retryAfter time.Duration)
+}
+
+// RespondWithRetryableError sends a standardized JSON error response with a Retry-After header in whole seconds.
+func RespondWithRetryableError(ctx context.Context, w http.ResponseWriter, statusCode int, message string, err error, retryAfter time.Duration, traceID string) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+
+	return RespondWithError(ctx, w, statusCode, message, err, traceID)
+}
+
+// RateLimitMiddleware rejects requests with 429 once limiter refuses the key returned by keyFn,
+// e.g. the client IP or API key.
+func RateLimitMiddleware(limiter Limiter, keyFn func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, retryAfter := limiter.Allow(keyFn(r))
+			if !allowed {
+				_ = RespondWithRetryableError(r.Context(), w, http.StatusTooManyRequests, "too many requests", ErrRateLimited, retryAfter, "")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// TokenBucketLimiter is an in-memory Limiter that keeps one token bucket per key.
+// A bucket that has been idle long enough to refill completely is indistinguishable from a new one,
+// so Allow periodically drops such buckets and memory stays proportional to the recently active keys.
+type TokenBucketLimiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// tokenBucket is the state of a single key.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucketLimiter allows ratePerSecond requests per key on average, with bursts of up to burst requests.
+func NewTokenBucketLimiter(ratePerSecond float64, burst int) *TokenBucketLimiter {
+	return &TokenBucketLimiter{
+		rate:      ratePerSecond,
+		burst:     float64(burst),
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow takes a token from key's bucket, reporting how long until one is available when it is empty.
+func (l *TokenBucketLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	if l.rate <= 0 {
+		return false, time.Minute
+	}
+	return false, time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep drops the buckets that have been idle long enough to refill to burst, at most once per refill period.
+// Without a positive rate buckets never refill, so nothing is dropped. The caller must hold l.mu.
+func (l *TokenBucketLimiter) sweep(now time.Time) {
+	if l.rate <= 0 {
+		return
+	}
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	if now.Sub(l.lastSweep) < refill {
+		return
+	}
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
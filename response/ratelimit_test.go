@@ -0,0 +1,38 @@
+package response
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestTokenBucketLimiterEvictsIdleBuckets(t *testing.T) {
+	// 1000 tokens per second with a burst of 1 refills an idle bucket within a millisecond
+	limiter := NewTokenBucketLimiter(1000, 1)
+
+	for i := 0; i < 100; i++ {
+		limiter.Allow(fmt.Sprintf("idle-%d", i))
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if allowed, _ := limiter.Allow("active"); !allowed {
+		t.Fatal("first request for a new key was rejected")
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	if len(limiter.buckets) != 1 {
+		t.Fatalf("got %d buckets after the idle keys refilled, want 1", len(limiter.buckets))
+	}
+}
+
+func TestTokenBucketLimiterKeepsLimitingActiveKeys(t *testing.T) {
+	limiter := NewTokenBucketLimiter(1, 1)
+
+	if allowed, _ := limiter.Allow("client"); !allowed {
+		t.Fatal("first request was rejected")
+	}
+	if allowed, retryAfter := limiter.Allow("client"); allowed || retryAfter <= 0 {
+		t.Fatalf("second request: allowed=%v retryAfter=%v, want rejected with a positive retryAfter", allowed, retryAfter)
+	}
+}
@@ -0,0 +1,32 @@
+package response
+
+// WithRedaction removes the given JSON keys from the encoded response body.
+// Keys are matched at any depth, including inside nested objects and slices.
+func WithRedaction(fields ...string) Option {
+	return func(o *options) {
+		if o.redactFields == nil {
+			o.redactFields = make(map[string]struct{}, len(fields))
+		}
+		for _, field := range fields {
+			o.redactFields[field] = struct{}{}
+		}
+	}
+}
+
+// redactValue walks decoded JSON in place and deletes matching keys.
+func redactValue(value interface{}, fields map[string]struct{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range v {
+			if _, ok := fields[key]; ok {
+				delete(v, key)
+				continue
+			}
+			redactValue(nested, fields)
+		}
+	case []interface{}:
+		for _, nested := range v {
+			redactValue(nested, fields)
+		}
+	}
+}
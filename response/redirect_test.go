@@ -0,0 +1,58 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRespondWithRedirectRejectsOpenRedirects(t *testing.T) {
+	SetRedirectAllowList("example.com")
+	defer SetRedirectAllowList()
+
+	tests := []struct {
+		target  string
+		allowed bool
+	}{
+		{"/dashboard", true},
+		{"/search?q=a%20b", true},
+		{"https://example.com/x", true},
+		{"//example.com/x", true},
+		{"//evil.com", false},
+		{" //evil.com", false},
+		{"\t//evil.com", false},
+		{"//evil.com ", false},
+		{"/\\evil.com", false},
+		{"\\\\evil.com", false},
+		{"///evil.com", false},
+		{"https://evil.com", false},
+		{"javascript:alert(1)", false},
+		{"/x\r\nSet-Cookie: a=b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+			err := RespondWithRedirect(w, r, tt.target, false)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("RespondWithRedirect(%q) = %v, want nil", tt.target, err)
+				}
+				if got := w.Header().Get("Location"); got != tt.target {
+					t.Fatalf("Location = %q, want %q", got, tt.target)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrUnsafeRedirect) {
+				t.Fatalf("RespondWithRedirect(%q) = %v, want ErrUnsafeRedirect", tt.target, err)
+			}
+			if got := w.Header().Get("Location"); got != "" {
+				t.Fatalf("Location = %q, want no header", got)
+			}
+		})
+	}
+}
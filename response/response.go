@@ -3,37 +3,97 @@ package response
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
 )
 
+// ErrResponse is the body of every error response. ErrorCode is a stable machine-readable
+// category, while TraceID references the request in the logs. Reason carries the error message,
+// which is replaced by a generic one for 5xx responses unless internal errors are exposed.
 type ErrResponse struct {
 	Code      int    `json:"code"`
-	Reason    string `json:"reason"`
+	Reason    string `json:"reason,omitempty"`
 	Message   string `json:"message"`
 	ErrorCode string `json:"error_code"`
+	TraceID   string `json:"trace_id,omitempty"`
 }
 
 // RespondWithError sends a standardized JSON error response.
-func RespondWithError(ctx context.Context, w http.ResponseWriter, statusCode int, message string, err error, traceID string) {
+// An empty traceID defaults to the correlation ID stored in ctx.
+// The returned error reports a failure to write the body; see IsClientDisconnect.
+func RespondWithError(ctx context.Context, w http.ResponseWriter, statusCode int, message string, err error, traceID string) error {
+	return writeError(ctx, w, statusCode, ErrorCodeForStatus(statusCode), message, err, traceID)
+}
+
+// writeError writes the error envelope with an explicit machine-readable error code.
+// When a 5xx reason is hidden from the client, the real error is logged with the trace ID instead.
+func writeError(ctx context.Context, w http.ResponseWriter, statusCode int, errorCode string, message string, err error, traceID string) error {
+	traceID = resolveTraceID(ctx, traceID)
+	if statusCode >= 500 && !exposesInternalErrors() {
+		logutil.LogError(traceID, "internal_error_suppressed", err, map[string]interface{}{
+			"code": statusCode,
+		})
+	}
+	return encodeError(w, statusCode, errorCode, message, err, traceID)
+}
+
+// encodeError writes the error envelope without logging, for callers that have already logged err.
+func encodeError(w http.ResponseWriter, statusCode int, errorCode string, message string, err error, traceID string) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+	writeHeader(w, statusCode)
 
 	response := ErrResponse{
 		Code:      statusCode,
 		Reason:    err.Error(),
 		Message:   message,
-		ErrorCode: traceID,
+		ErrorCode: errorCode,
+		TraceID:   traceID,
+	}
+	if statusCode >= 500 && !exposesInternalErrors() {
+		response.Reason = suppressedReason
 	}
 
-	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+	return json.NewEncoder(w).Encode(map[string]interface{}{
 		"error": response,
 	})
 }
 
 // RespondWithSuccess sends a standardized JSON success response.
-func RespondWithSuccess(ctx context.Context, w http.ResponseWriter, statusCode int, data interface{}) {
+// Options such as WithRedaction are applied to data before it is encoded.
+// Nil data with a 2xx status is written according to SetNilDataBehavior.
+// The returned error reports a failure to encode data, after a 500 has been sent in its place,
+// or to write the body; see IsClientDisconnect.
+func RespondWithSuccess(ctx context.Context, w http.ResponseWriter, statusCode int, data interface{}, opts ...Option) error {
+	if statusCode >= 200 && statusCode < 300 && isNilData(data) {
+		switch nilDataBehavior {
+		case EmptyBody:
+			writeHeader(w, statusCode)
+			return nil
+		case EmptyObject:
+			data = struct{}{}
+		}
+	}
+
+	o := newOptions(opts)
+	if len(o.redactFields) > 0 || largeIntAsString {
+		generic, err := toGeneric(data)
+		if err != nil {
+			_ = RespondWithError(ctx, w, http.StatusInternalServerError, "failed to encode response", err, "")
+			return fmt.Errorf("encode response: %w", err)
+		}
+		if len(o.redactFields) > 0 {
+			redactValue(generic, o.redactFields)
+		}
+		if largeIntAsString {
+			generic = stringifyLargeInts(generic)
+		}
+		data = generic
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+	writeHeader(w, statusCode)
 
-	_ = json.NewEncoder(w).Encode(data)
+	return json.NewEncoder(w).Encode(data)
 }
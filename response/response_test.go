@@ -0,0 +1,47 @@
+package response
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestRespondWithErrorReasonByEnvironment(t *testing.T) {
+	tests := []struct {
+		appEnv     string
+		wantReason string
+	}{
+		{"", "connection refused"},
+		{"development", "connection refused"},
+		{"production", suppressedReason},
+	}
+
+	for _, tt := range tests {
+		t.Run("APP_ENV="+tt.appEnv, func(t *testing.T) {
+			t.Setenv("APP_ENV", tt.appEnv)
+			if tt.appEnv == "" {
+				os.Unsetenv("APP_ENV")
+			}
+
+			w := httptest.NewRecorder()
+			err := RespondWithError(context.Background(), w, http.StatusInternalServerError, "failed", errors.New("connection refused"), "t-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var body struct {
+				Error ErrResponse `json:"error"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error.Reason != tt.wantReason {
+				t.Fatalf("got reason %q, want %q", body.Error.Reason, tt.wantReason)
+			}
+		})
+	}
+}
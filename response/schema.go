@@ -0,0 +1,69 @@
+package response
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ErrorSchema returns a JSON Schema fragment for the error envelope ({"error": ErrResponse}),
+// ready to embed in an OpenAPI document. It is derived from ErrResponse so it follows the struct as it evolves.
+func ErrorSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"error"},
+		"properties": map[string]interface{}{
+			"error": structSchema(reflect.TypeOf(ErrResponse{})),
+		},
+	}
+}
+
+// structSchema describes the JSON-encoded fields of t; fields without omitempty are required.
+func structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	required := []string{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = map[string]interface{}{"type": jsonSchemaType(field.Type)}
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"required":   required,
+		"properties": properties,
+	}
+}
+
+// jsonSchemaType maps a Go type to its JSON Schema type name.
+func jsonSchemaType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,80 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ErrStreamClosed is returned by streaming writers once the client has gone away or the writer was closed.
+// Errors caused by a failed write wrap both ErrStreamClosed and the write error.
+var ErrStreamClosed = errors.New("stream closed")
+
+// streamFlushInterval is the minimum time between automatic flushes of a streaming writer.
+const streamFlushInterval = 100 * time.Millisecond
+
+// streamBase is shared by the streaming writers. It stops writing as soon as the request context is done
+// or a write fails, so handlers see ErrStreamClosed instead of a stream of write errors.
+type streamBase struct {
+	w         http.ResponseWriter
+	flusher   http.Flusher
+	lastFlush time.Time
+
+	closed   atomic.Bool
+	stopWait func() bool
+}
+
+// newStreamBase writes contentType and statusCode and starts watching ctx for cancellation.
+func newStreamBase(ctx context.Context, w http.ResponseWriter, contentType string, statusCode int) *streamBase {
+	w.Header().Set("Content-Type", contentType)
+	writeHeader(w, statusCode)
+
+	flusher, _ := w.(http.Flusher)
+	s := &streamBase{
+		w:         w,
+		flusher:   flusher,
+		lastFlush: time.Now(),
+	}
+	s.stopWait = context.AfterFunc(ctx, func() {
+		s.closed.Store(true)
+	})
+	return s
+}
+
+// write sends p to the client, flushing periodically. It returns ErrStreamClosed once the stream is gone.
+func (s *streamBase) write(p []byte) error {
+	if s.closed.Load() {
+		return ErrStreamClosed
+	}
+
+	if _, err := s.w.Write(p); err != nil {
+		s.closed.Store(true)
+		return fmt.Errorf("%w: %w", ErrStreamClosed, err)
+	}
+
+	if time.Since(s.lastFlush) >= streamFlushInterval {
+		s.flush()
+	}
+	return nil
+}
+
+// flush sends any buffered output to the client immediately, unless the stream is gone.
+func (s *streamBase) flush() {
+	if s.closed.Load() {
+		return
+	}
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+	s.lastFlush = time.Now()
+}
+
+// close stops watching the request context and marks the stream closed.
+// It reports whether the client was still connected, i.e. whether final framing should be written.
+func (s *streamBase) close() bool {
+	s.stopWait()
+	return !s.closed.Swap(true)
+}
@@ -0,0 +1,129 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrRequestTimeout is the error reported when TimeoutMiddleware cuts off a slow handler.
+var ErrRequestTimeout = errors.New("request timed out")
+
+// TimeoutMiddleware cancels the request context after d and responds 504 if the handler has not written anything yet.
+// Writes the handler attempts after the timeout are discarded and return http.ErrHandlerTimeout.
+func TimeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			r = r.WithContext(ctx)
+
+			tw := &timeoutWriter{w: w, header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+				next.ServeHTTP(tw, r)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+
+				// A handler that never wrote still expects its headers on the implicit 200.
+				if !tw.wroteHeader {
+					dst := w.Header()
+					for k, v := range tw.header {
+						dst[k] = v
+					}
+				}
+			case p := <-panicked:
+				panic(p)
+			case <-ctx.Done():
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+
+				tw.timedOut = true
+				if !tw.wroteHeader && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					_ = RespondWithError(ctx, w, http.StatusGatewayTimeout, "request timed out", ErrRequestTimeout, "")
+				}
+			}
+		})
+	}
+}
+
+// timeoutWriter guards the real writer so the handler and the timeout never write concurrently.
+// The handler gets its own header map, copied to the real writer on its first write.
+type timeoutWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+
+	mu          sync.Mutex
+	wroteHeader bool
+	timedOut    bool
+}
+
+// Header returns the handler's private header map.
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+// WriteHeader forwards the status unless the request already timed out.
+func (tw *timeoutWriter) WriteHeader(statusCode int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.writeHeaderLocked(statusCode)
+}
+
+// Write forwards the body unless the request already timed out.
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteHeader {
+		tw.writeHeaderLocked(http.StatusOK)
+	}
+	return tw.w.Write(b)
+}
+
+// Flush forwards to the real writer when it supports streaming.
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return
+	}
+	if !tw.wroteHeader {
+		tw.writeHeaderLocked(http.StatusOK)
+	}
+	if flusher, ok := tw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// writeHeaderLocked copies the handler's headers and writes the status; the caller must hold tw.mu.
+func (tw *timeoutWriter) writeHeaderLocked(statusCode int) {
+	dst := tw.w.Header()
+	for k, v := range tw.header {
+		dst[k] = v
+	}
+	tw.wroteHeader = true
+	tw.w.WriteHeader(statusCode)
+}
@@ -0,0 +1,23 @@
+package response
+
+import (
+	"context"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+// TraceIDFromContext returns the correlation ID stored in ctx by the logging middleware, or an empty string.
+func TraceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	return logutil.CorrelationIDFromContext(ctx)
+}
+
+// resolveTraceID returns traceID, falling back to the correlation ID stored in ctx when it is empty.
+func resolveTraceID(ctx context.Context, traceID string) string {
+	if traceID != "" {
+		return traceID
+	}
+	return TraceIDFromContext(ctx)
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrUnauthorized is reported by RespondWithUnauthorized when it is called without an error.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrInvalidToken marks errors caused by an expired, revoked or malformed bearer token.
+	// Wrap it so RespondWithUnauthorized adds error="invalid_token" to the challenge (RFC 6750).
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// RespondWithUnauthorized sends a 401 error envelope with a WWW-Authenticate challenge for scheme
+// ("Bearer" or "Basic") and realm. For Bearer challenges, errors wrapping ErrInvalidToken add
+// error="invalid_token" so clients know to refresh their token.
+func RespondWithUnauthorized(ctx context.Context, w http.ResponseWriter, scheme, realm string, err error) error {
+	if err == nil {
+		err = ErrUnauthorized
+	}
+
+	challenge := scheme + ` realm="` + quoteEscape(realm) + `"`
+	if strings.EqualFold(scheme, "Bearer") && errors.Is(err, ErrInvalidToken) {
+		challenge += `, error="invalid_token"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+
+	return RespondWithError(ctx, w, http.StatusUnauthorized, "unauthorized", err, "")
+}
+
+// quoteEscape escapes s for use inside a quoted-string header parameter.
+func quoteEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
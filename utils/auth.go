@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// ParseBearerToken returns the token of an "Authorization: Bearer <token>" header.
+// The scheme is matched case-insensitively; a missing header, another scheme or an empty token reports false.
+func ParseBearerToken(r *http.Request) (string, bool) {
+	token, ok := authorizationCredentials(r, "Bearer")
+	if !ok || token == "" || strings.ContainsAny(token, " \t") {
+		return "", false
+	}
+	return token, true
+}
+
+// ParseBasicAuth returns the user and password of an "Authorization: Basic <base64>" header.
+// Unlike http.Request.BasicAuth it tolerates extra whitespace and unpadded base64.
+func ParseBasicAuth(r *http.Request) (user, pass string, ok bool) {
+	encoded, ok := authorizationCredentials(r, "Basic")
+	if !ok || encoded == "" {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		decoded, err = base64.RawStdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", "", false
+		}
+	}
+
+	user, pass, ok = strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", false
+	}
+	return user, pass, true
+}
+
+// authorizationCredentials splits the Authorization header and returns its credentials when the scheme matches.
+func authorizationCredentials(r *http.Request, scheme string) (string, bool) {
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	if len(header) <= len(scheme) || !strings.EqualFold(header[:len(scheme)], scheme) {
+		return "", false
+	}
+
+	rest := header[len(scheme):]
+	if rest[0] != ' ' && rest[0] != '\t' {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Build metadata, normally injected at link time, e.g.
+//
+//	go build -ldflags "-X github.com/Ehsan-Eghbali/common/utils.Version=v1.2.3 -X github.com/Ehsan-Eghbali/common/utils.Commit=abc123"
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+var (
+	buildInfoMu sync.RWMutex
+	startTime   = time.Now()
+)
+
+// BuildInfo describes the running binary.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// SetBuildInfo overrides the build metadata for binaries that cannot use ldflags.
+func SetBuildInfo(version, commit, buildTime string) {
+	buildInfoMu.Lock()
+	defer buildInfoMu.Unlock()
+
+	Version = version
+	Commit = commit
+	BuildTime = buildTime
+}
+
+// GetBuildInfo returns the current build metadata.
+func GetBuildInfo() BuildInfo {
+	buildInfoMu.RLock()
+	defer buildInfoMu.RUnlock()
+
+	return BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// Uptime returns how long the process has been running, measured from package initialization.
+func Uptime() time.Duration {
+	return time.Since(startTime)
+}
@@ -0,0 +1,152 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Coerce converts value to targetType. Strings are parsed into numbers, bools, time.Time (RFC 3339)
+// and time.Duration, and numbers are converted between numeric types when the value fits exactly,
+// e.g. 3.0 becomes int 3 but 3.5 or 300 into int8 are errors, as is an int64 above 2^53 into float64.
+// json.Number values follow the numeric rules, so "3.0" converts to int as well.
+func Coerce(value interface{}, targetType reflect.Type) (interface{}, error) {
+	if value == nil {
+		return nil, fmt.Errorf("cannot coerce nil to %s", targetType)
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Type() == targetType {
+		return value, nil
+	}
+
+	if n, ok := value.(json.Number); ok {
+		if isNumericKind(targetType.Kind()) {
+			number, err := parseJSONNumber(n)
+			if err != nil {
+				return nil, fmt.Errorf("cannot coerce %q to %s: %w", n.String(), targetType, err)
+			}
+			value, v = number, reflect.ValueOf(number)
+		} else {
+			value, v = n.String(), reflect.ValueOf(n.String())
+		}
+	}
+
+	out := reflect.New(targetType).Elem()
+	if v.Kind() == reflect.String {
+		if err := setQueryValue(out, v.String()); err != nil {
+			return nil, fmt.Errorf("cannot coerce %q to %s: %w", v.String(), targetType, err)
+		}
+		return out.Interface(), nil
+	}
+
+	if isNumericKind(v.Kind()) && isNumericKind(targetType.Kind()) {
+		if err := setNumeric(out, v); err != nil {
+			return nil, fmt.Errorf("cannot coerce %v to %s: %w", value, targetType, err)
+		}
+		return out.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("cannot coerce %T to %s", value, targetType)
+}
+
+// parseJSONNumber returns n as an int64 or uint64 when it is written as an integer and as a float64
+// otherwise, so "3.0" can still become an int through the whole-number check in setNumeric.
+func parseJSONNumber(n json.Number) (interface{}, error) {
+	if i, err := n.Int64(); err == nil {
+		return i, nil
+	}
+	if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		return u, nil
+	}
+	return n.Float64()
+}
+
+// isNumericKind reports whether k is an integer or floating-point kind.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// setNumeric stores the number src in dst, failing when it cannot be represented exactly.
+func setNumeric(dst, src reflect.Value) error {
+	var f float64
+	switch {
+	case src.CanInt():
+		f = float64(src.Int())
+	case src.CanUint():
+		f = float64(src.Uint())
+	default:
+		f = src.Float()
+	}
+
+	switch {
+	case dst.CanInt():
+		var n int64
+		switch {
+		case src.CanInt():
+			n = src.Int()
+		case src.CanUint():
+			if src.Uint() > math.MaxInt64 {
+				return fmt.Errorf("value overflows %s", dst.Type())
+			}
+			n = int64(src.Uint())
+		default:
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return fmt.Errorf("value is not a whole number in range")
+			}
+			n = int64(f)
+		}
+		if dst.OverflowInt(n) {
+			return fmt.Errorf("value overflows %s", dst.Type())
+		}
+		dst.SetInt(n)
+	case dst.CanUint():
+		var n uint64
+		switch {
+		case src.CanInt():
+			if src.Int() < 0 {
+				return fmt.Errorf("negative value for %s", dst.Type())
+			}
+			n = uint64(src.Int())
+		case src.CanUint():
+			n = src.Uint()
+		default:
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+				return fmt.Errorf("value is not a non-negative whole number in range")
+			}
+			n = uint64(f)
+		}
+		if dst.OverflowUint(n) {
+			return fmt.Errorf("value overflows %s", dst.Type())
+		}
+		dst.SetUint(n)
+	default:
+		if dst.OverflowFloat(f) {
+			return fmt.Errorf("value overflows %s", dst.Type())
+		}
+		if (src.CanInt() || src.CanUint()) && !exactInFloat(src, f, dst.Kind()) {
+			return fmt.Errorf("value cannot be represented exactly as %s", dst.Type())
+		}
+		dst.SetFloat(f)
+	}
+	return nil
+}
+
+// exactInFloat reports whether the integer src survives a round trip through f stored as a float of kind k.
+func exactInFloat(src reflect.Value, f float64, k reflect.Kind) bool {
+	if k == reflect.Float32 {
+		f = float64(float32(f))
+	}
+	if src.CanInt() {
+		return f >= math.MinInt64 && f < math.MaxInt64 && int64(f) == src.Int()
+	}
+	return f >= 0 && f < math.MaxUint64 && uint64(f) == src.Uint()
+}
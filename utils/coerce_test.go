@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCoerceNumbers(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		target  reflect.Type
+		want    interface{}
+		wantErr bool
+	}{
+		{"float to int", 3.0, reflect.TypeOf(int(0)), 3, false},
+		{"fractional float to int", 3.5, reflect.TypeOf(int(0)), nil, true},
+		{"small int to float64", int64(1 << 53), reflect.TypeOf(float64(0)), float64(1 << 53), false},
+		{"lossy int64 to float64", int64(1<<53 + 1), reflect.TypeOf(float64(0)), nil, true},
+		{"lossy int32 to float32", int32(1<<24 + 1), reflect.TypeOf(float32(0)), nil, true},
+		{"lossy uint64 to float64", uint64(1<<63 + 1), reflect.TypeOf(float64(0)), nil, true},
+		{"json whole float to int", json.Number("3.0"), reflect.TypeOf(int(0)), 3, false},
+		{"json fraction to int", json.Number("3.5"), reflect.TypeOf(int(0)), nil, true},
+		{"json large int to int64", json.Number("9007199254740993"), reflect.TypeOf(int64(0)), int64(9007199254740993), false},
+		{"json max uint64", json.Number("18446744073709551615"), reflect.TypeOf(uint64(0)), uint64(18446744073709551615), false},
+		{"json lossy int to float64", json.Number("9007199254740993"), reflect.TypeOf(float64(0)), nil, true},
+		{"json float to float64", json.Number("0.25"), reflect.TypeOf(float64(0)), 0.25, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Coerce(tt.value, tt.target)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Coerce(%v, %s) = %v, want an error", tt.value, tt.target, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Coerce(%v, %s): %v", tt.value, tt.target, err)
+			}
+			if got != tt.want {
+				t.Fatalf("Coerce(%v, %s) = %#v, want %#v", tt.value, tt.target, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// ConfigError reports a configuration value that could not be applied to its field.
+type ConfigError struct {
+	Source string
+	Field  string
+	Value  string
+	Err    error
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("config %s: invalid value %q for field %s: %v", e.Source, e.Value, e.Field, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigLoader resolves configuration into a struct from layered sources, later sources winning:
+// Defaults, then the JSON File, then environment variables named after the field path with EnvPrefix,
+// e.g. APP_DATABASE_HOST for Database.Host with the prefix "APP". CamelCase field names become
+// upper snake case (MaxConns → MAX_CONNS) and an `env` tag overrides a field's name segment.
+// Nested structs and pointers to structs are followed; a nil pointer is only allocated when one of its variables is set.
+// Empty Defaults, File or EnvPrefix skip that layer, so unrelated variables such as PATH or HOME are never read.
+type ConfigLoader struct {
+	Defaults  interface{}
+	File      string
+	EnvPrefix string
+}
+
+// Load fills the struct pointed to by dst from every configured source.
+// Every environment variable that fails to parse is reported, joined into one error of *ConfigError values.
+func (l ConfigLoader) Load(dst interface{}) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return errors.New("ConfigLoader: dst must be a non-nil pointer to a struct")
+	}
+
+	if l.Defaults != nil {
+		data, err := json.Marshal(l.Defaults)
+		if err != nil {
+			return fmt.Errorf("config defaults: %w", err)
+		}
+		if err := json.Unmarshal(data, dst); err != nil {
+			return fmt.Errorf("config defaults: %w", err)
+		}
+	}
+
+	if l.File != "" {
+		data, err := os.ReadFile(l.File)
+		if err != nil {
+			return fmt.Errorf("config file: %w", err)
+		}
+		if err := json.Unmarshal(data, dst); err != nil {
+			return fmt.Errorf("config file %s: %w", l.File, err)
+		}
+	}
+
+	if l.EnvPrefix == "" {
+		return nil
+	}
+	_, errs := applyEnv(target.Elem(), l.EnvPrefix, "")
+	return errors.Join(errs...)
+}
+
+// applyEnv overrides the fields of the struct v from environment variables named prefix_FIELD.
+// It reports whether any variable was found.
+func applyEnv(v reflect.Value, prefix, path string) (found bool, errs []error) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		segment := field.Tag.Get("env")
+		if segment == "-" {
+			continue
+		}
+		if segment == "" {
+			segment = upperSnakeCase(field.Name)
+		}
+		key := prefix + "_" + segment
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		target := v.Field(i)
+		if target.Kind() == reflect.Pointer {
+			// Work on a copy so a nil pointer stays nil when none of its variables are set.
+			elem := reflect.New(target.Type().Elem())
+			if !target.IsNil() {
+				elem.Elem().Set(target.Elem())
+			}
+			fieldFound, fieldErrs := applyEnvField(elem.Elem(), key, fieldPath)
+			if fieldFound {
+				target.Set(elem)
+				found = true
+			}
+			errs = append(errs, fieldErrs...)
+			continue
+		}
+
+		fieldFound, fieldErrs := applyEnvField(target, key, fieldPath)
+		found = found || fieldFound
+		errs = append(errs, fieldErrs...)
+	}
+	return found, errs
+}
+
+// applyEnvField overrides a single field, recursing into structs other than time.Time.
+func applyEnvField(target reflect.Value, key, fieldPath string) (bool, []error) {
+	if target.Kind() == reflect.Struct && target.Type() != timeType {
+		return applyEnv(target, key, fieldPath)
+	}
+
+	raw, ok := os.LookupEnv(key)
+	if !ok {
+		return false, nil
+	}
+	if err := setEnvValue(target, raw); err != nil {
+		return true, []error{&ConfigError{Source: "env " + key, Field: fieldPath, Value: raw, Err: err}}
+	}
+	return true, nil
+}
+
+// setEnvValue parses raw into v. Bools accept every ParseBool spelling and slices take comma-separated values.
+func setEnvValue(v reflect.Value, raw string) error {
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Slice:
+		parts := strings.Split(raw, ",")
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setEnvValue(slice.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+	return setQueryValue(v, raw)
+}
+
+// upperSnakeCase converts a Go field name such as MaxConns or HTTPPort to MAX_CONNS or HTTP_PORT.
+func upperSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidEmail is wrapped by every error NormalizeEmail returns.
+	ErrInvalidEmail = errors.New("invalid email")
+	// ErrInvalidPhone is wrapped by every error NormalizePhoneE164 returns.
+	ErrInvalidPhone = errors.New("invalid phone number")
+)
+
+// regionCallingCodes maps ISO 3166-1 alpha-2 regions to their country calling codes.
+var regionCallingCodes = map[string]string{
+	"US": "1", "CA": "1", "GB": "44", "IE": "353", "DE": "49", "FR": "33",
+	"ES": "34", "IT": "39", "NL": "31", "BE": "32", "CH": "41", "AT": "43",
+	"SE": "46", "NO": "47", "DK": "45", "FI": "358", "PL": "48", "PT": "351",
+	"TR": "90", "IR": "98", "AE": "971", "SA": "966", "IN": "91", "PK": "92",
+	"CN": "86", "JP": "81", "KR": "82", "AU": "61", "NZ": "64", "BR": "55",
+	"MX": "52", "AR": "54", "ZA": "27", "EG": "20", "NG": "234", "RU": "7",
+}
+
+// NormalizeEmail trims s, lowercases its domain and checks that it has a basic local@domain.tld shape.
+func NormalizeEmail(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	at := strings.LastIndexByte(s, '@')
+	if at < 0 {
+		return "", fmt.Errorf("%w: missing @", ErrInvalidEmail)
+	}
+
+	local, domain := s[:at], strings.ToLower(s[at+1:])
+	if local == "" || len(local) > 64 {
+		return "", fmt.Errorf("%w: local part must be 1 to 64 characters", ErrInvalidEmail)
+	}
+	if strings.ContainsAny(local, " \t\"(),:;<>@[\\]") {
+		return "", fmt.Errorf("%w: local part contains invalid characters", ErrInvalidEmail)
+	}
+	if len(domain) > 253 || !strings.Contains(domain, ".") {
+		return "", fmt.Errorf("%w: domain %q is not a valid host name", ErrInvalidEmail, domain)
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if !isHostLabel(label) {
+			return "", fmt.Errorf("%w: domain %q is not a valid host name", ErrInvalidEmail, domain)
+		}
+	}
+
+	return local + "@" + domain, nil
+}
+
+// isHostLabel reports whether label is a valid DNS label.
+func isHostLabel(label string) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizePhoneE164 converts s to E.164 (+<country code><number>).
+// Numbers without an international prefix (+ or 00) are assumed to belong to defaultRegion,
+// in which case a leading trunk prefix 0 is dropped. Only the length is validated, not number ranges.
+func NormalizePhoneE164(s, defaultRegion string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", fmt.Errorf("%w: empty", ErrInvalidPhone)
+	}
+
+	international := strings.HasPrefix(s, "+")
+	var digits strings.Builder
+	for i, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == '+' && i == 0:
+		case c == ' ' || c == '-' || c == '.' || c == '(' || c == ')':
+		default:
+			return "", fmt.Errorf("%w: unexpected character %q", ErrInvalidPhone, c)
+		}
+	}
+
+	number := digits.String()
+	if !international && strings.HasPrefix(number, "00") {
+		international = true
+		number = number[2:]
+	}
+
+	if !international {
+		code, ok := regionCallingCodes[strings.ToUpper(defaultRegion)]
+		if !ok {
+			return "", fmt.Errorf("%w: unknown region %q", ErrInvalidPhone, defaultRegion)
+		}
+		number = code + strings.TrimPrefix(number, "0")
+	}
+
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+		return "", fmt.Errorf("%w: must have 8 to 15 digits including the country code", ErrInvalidPhone)
+	}
+	return "+" + number, nil
+}
@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// extensionContentTypes lists the types http.DetectContentType cannot recognize from content alone,
+// usually reporting them as text/plain.
+var extensionContentTypes = map[string]string{
+	".csv":    "text/csv; charset=utf-8",
+	".tsv":    "text/tab-separated-values; charset=utf-8",
+	".json":   "application/json",
+	".ndjson": "application/x-ndjson",
+	".js":     "text/javascript; charset=utf-8",
+	".css":    "text/css; charset=utf-8",
+	".svg":    "image/svg+xml",
+	".md":     "text/markdown; charset=utf-8",
+	".yaml":   "application/yaml",
+	".yml":    "application/yaml",
+}
+
+// DetectContentType returns the content type of data, using filename's extension for
+// the text formats that content sniffing gets wrong (CSV, JSON, SVG and similar).
+// Otherwise it sniffs data with http.DetectContentType, which only inspects the first 512 bytes;
+// empty data falls back to application/octet-stream.
+func DetectContentType(data []byte, filename string) string {
+	if contentType, ok := extensionContentTypes[strings.ToLower(filepath.Ext(filename))]; ok {
+		return contentType
+	}
+	if len(data) == 0 {
+		return "application/octet-stream"
+	}
+	return http.DetectContentType(data)
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidNumber is returned when a value is not a valid JSON number or does not fit the requested form.
+var ErrInvalidNumber = errors.New("invalid number")
+
+// maxNumberExponent bounds the exponent accepted by NumberToRat.
+const maxNumberExponent = 1000
+
+// jsonNumberPattern matches the JSON number grammar.
+var jsonNumberPattern = regexp.MustCompile(`^-?(?:0|[1-9]\d*)(?:\.\d+)?(?:[eE][+-]?\d+)?$`)
+
+// DecodeJSONBody decodes the request body into dst with json.Decoder.UseNumber, so numbers decoded
+// into interface{} values become json.Number instead of lossy float64s. Trailing data after the value is an error.
+func DecodeJSONBody(r *http.Request, dst interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+
+	if err := decoder.Decode(dst); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("request body must contain a single JSON value")
+	}
+	return nil
+}
+
+// DecodeJSONNumber returns the exact text of the JSON number in raw, without going through float64.
+// Numbers quoted as JSON strings, as some clients send money amounts, are accepted too.
+func DecodeJSONNumber(raw json.RawMessage) (string, error) {
+	text := string(bytes.TrimSpace(raw))
+	if strings.HasPrefix(text, `"`) {
+		var quoted string
+		if err := json.Unmarshal([]byte(text), &quoted); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidNumber, err)
+		}
+		text = strings.TrimSpace(quoted)
+	}
+
+	if !jsonNumberPattern.MatchString(text) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidNumber, text)
+	}
+	return text, nil
+}
+
+// NumberToRat converts a number string from DecodeJSONNumber (or a json.Number) into an exact big.Rat.
+func NumberToRat(number string) (*big.Rat, error) {
+	if !jsonNumberPattern.MatchString(number) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNumber, number)
+	}
+
+	// Huge exponents would make big.Rat allocate enormous integers.
+	if i := strings.IndexAny(number, "eE"); i >= 0 {
+		exponent, err := strconv.Atoi(number[i+1:])
+		if err != nil || exponent > maxNumberExponent || exponent < -maxNumberExponent {
+			return nil, fmt.Errorf("%w: %q exponent out of range", ErrInvalidNumber, number)
+		}
+	}
+
+	rat, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidNumber, number)
+	}
+	return rat, nil
+}
+
+// NumberToCents converts a decimal amount such as "12.34" into integer cents (1234).
+// Amounts with fractions of a cent or outside the int64 range are rejected rather than rounded.
+func NumberToCents(number string) (int64, error) {
+	rat, err := NumberToRat(number)
+	if err != nil {
+		return 0, err
+	}
+
+	cents := rat.Mul(rat, big.NewRat(100, 1))
+	if !cents.IsInt() {
+		return 0, fmt.Errorf("%w: %q has fractions of a cent", ErrInvalidNumber, number)
+	}
+	if !cents.Num().IsInt64() {
+		return 0, fmt.Errorf("%w: %q is out of range", ErrInvalidNumber, number)
+	}
+	return cents.Num().Int64(), nil
+}
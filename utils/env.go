@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Ehsan-Eghbali/common/internal/env"
+)
+
+// Env is a deployment environment, read from the APP_ENV variable.
+type Env = env.Env
+
+const (
+	Unset       = env.Unset
+	Development = env.Development
+	Staging     = env.Staging
+	Production  = env.Production
+)
+
+// Environment returns the environment named by APP_ENV ("development"/"dev"/"local", "staging"/"stage",
+// "production"/"prod"). It returns Unset when APP_ENV is not set, in which case neither IsProduction nor
+// IsDevelopment holds and the environment-aware defaults keep their previous behavior: JSON logs and
+// error reasons in responses. Unrecognized values are treated as production.
+func Environment() Env {
+	return env.Current()
+}
+
+// IsProduction reports whether the service runs in production.
+func IsProduction() bool {
+	return Environment() == Production
+}
+
+// IsDevelopment reports whether the service runs in development.
+func IsDevelopment() bool {
+	return Environment() == Development
+}
+
+// ParseBool parses 1/0, true/false, yes/no, on/off and t/f, case-insensitively and ignoring surrounding spaces.
+// Any other value is an error, so misconfigured flags are caught instead of silently defaulting.
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "1", "true", "t", "yes", "y", "on":
+		return true, nil
+	case "0", "false", "f", "no", "n", "off":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid boolean %q: expected one of 1/0, true/false, yes/no, on/off", s)
+	}
+}
+
+// GetEnvBool reads the environment variable key with ParseBool, returning fallback when it is unset or empty.
+func GetEnvBool(key string, fallback bool) (bool, error) {
+	raw, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return fallback, nil
+	}
+
+	value, err := ParseBool(raw)
+	if err != nil {
+		return fallback, fmt.Errorf("%s: %w", key, err)
+	}
+	return value, nil
+}
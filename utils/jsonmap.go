@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// JSONGetString returns m[key] if it is a string.
+func JSONGetString(m map[string]interface{}, key string) (string, bool) {
+	v, ok := m[key].(string)
+	return v, ok
+}
+
+// JSONGetInt returns m[key] as an integer. JSON numbers decoded as float64 or json.Number are accepted
+// only when they hold a whole value that fits in an int64.
+func JSONGetInt(m map[string]interface{}, key string) (int64, bool) {
+	switch v := m[key].(type) {
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case int32:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// JSONGetFloat returns m[key] as a float64.
+func JSONGetFloat(m map[string]interface{}, key string) (float64, bool) {
+	switch v := m[key].(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// JSONGetBool returns m[key] if it is a bool.
+func JSONGetBool(m map[string]interface{}, key string) (bool, bool) {
+	v, ok := m[key].(bool)
+	return v, ok
+}
+
+// JSONGetSlice returns m[key] if it is a JSON array.
+func JSONGetSlice(m map[string]interface{}, key string) ([]interface{}, bool) {
+	v, ok := m[key].([]interface{})
+	return v, ok
+}
+
+// JSONGetMap returns m[key] if it is a JSON object.
+func JSONGetMap(m map[string]interface{}, key string) (map[string]interface{}, bool) {
+	v, ok := m[key].(map[string]interface{})
+	return v, ok
+}
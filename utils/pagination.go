@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ErrInvalidPagination is wrapped by every error ParsePagination returns, so callers can map it to 400.
+var ErrInvalidPagination = errors.New("invalid pagination")
+
+// PaginationDefaults configures ParsePagination. Zero values fall back to page 1, a page size of 20 and a max of 100.
+type PaginationDefaults struct {
+	Page        int
+	PageSize    int
+	MaxPageSize int
+}
+
+// Pagination is the validated paging request.
+type Pagination struct {
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+	Offset   int `json:"-"`
+}
+
+// ParsePagination reads the page and page_size query parameters, validates them and clamps page_size to the maximum.
+func ParsePagination(r *http.Request, defaults PaginationDefaults) (Pagination, error) {
+	if defaults.Page <= 0 {
+		defaults.Page = 1
+	}
+	if defaults.PageSize <= 0 {
+		defaults.PageSize = 20
+	}
+	if defaults.MaxPageSize <= 0 {
+		defaults.MaxPageSize = 100
+	}
+
+	query := r.URL.Query()
+
+	page, err := parsePositiveInt(query.Get("page"), defaults.Page)
+	if err != nil {
+		return Pagination{}, fmt.Errorf("%w: page %v", ErrInvalidPagination, err)
+	}
+
+	pageSize, err := parsePositiveInt(query.Get("page_size"), defaults.PageSize)
+	if err != nil {
+		return Pagination{}, fmt.Errorf("%w: page_size %v", ErrInvalidPagination, err)
+	}
+	if pageSize > defaults.MaxPageSize {
+		pageSize = defaults.MaxPageSize
+	}
+
+	return Pagination{
+		Page:     page,
+		PageSize: pageSize,
+		Offset:   (page - 1) * pageSize,
+	}, nil
+}
+
+// parsePositiveInt parses raw as an integer greater than zero, returning fallback when raw is empty.
+func parsePositiveInt(raw string, fallback int) (int, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("must be an integer, got %q", raw)
+	}
+	if value < 1 {
+		return 0, fmt.Errorf("must be greater than zero, got %d", value)
+	}
+	return value, nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsafePath is wrapped by every error SafeJoin returns.
+var ErrUnsafePath = errors.New("unsafe path")
+
+// SafeJoin joins a client-supplied path onto base and guarantees the result stays inside base.
+// Absolute paths and paths escaping base through ".." are rejected. Symlinks in the existing part
+// of the path are resolved, so a link inside base cannot point the result outside of it.
+func SafeJoin(base, userPath string) (string, error) {
+	if userPath == "" {
+		return "", fmt.Errorf("%w: empty path", ErrUnsafePath)
+	}
+	if filepath.IsAbs(userPath) || strings.HasPrefix(userPath, "/") || strings.HasPrefix(userPath, `\`) || filepath.VolumeName(userPath) != "" {
+		return "", fmt.Errorf("%w: absolute path %q", ErrUnsafePath, userPath)
+	}
+
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return "", err
+	}
+	joined := filepath.Join(absBase, userPath)
+	if !within(absBase, joined) {
+		return "", fmt.Errorf("%w: %q escapes the base directory", ErrUnsafePath, userPath)
+	}
+
+	realBase, err := filepath.EvalSymlinks(absBase)
+	if err != nil {
+		return "", err
+	}
+	realJoined, err := evalExistingSymlinks(joined)
+	if err != nil {
+		return "", err
+	}
+	if !within(realBase, realJoined) {
+		return "", fmt.Errorf("%w: %q resolves outside the base directory", ErrUnsafePath, userPath)
+	}
+
+	return joined, nil
+}
+
+// within reports whether target is base or lies below it.
+func within(base, target string) bool {
+	rel, err := filepath.Rel(base, target)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// evalExistingSymlinks resolves symlinks in the longest existing prefix of path and appends the rest unchanged.
+func evalExistingSymlinks(path string) (string, error) {
+	var rest []string
+	current := path
+	for {
+		resolved, err := filepath.EvalSymlinks(current)
+		if err == nil {
+			return filepath.Join(append([]string{resolved}, rest...)...), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return path, nil
+		}
+		rest = append([]string{filepath.Base(current)}, rest...)
+		current = parent
+	}
+}
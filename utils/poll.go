@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// ErrPollAttemptsExceeded is returned by Poll when the attempt cap is reached before fn reports done.
+var ErrPollAttemptsExceeded = errors.New("poll attempts exceeded")
+
+// PollOption configures Poll.
+type PollOption func(*pollConfig)
+
+// pollConfig holds the settings applied by PollOption values.
+type pollConfig struct {
+	maxAttempts int
+	factor      float64
+	maxInterval time.Duration
+	jitter      float64
+}
+
+// WithMaxAttempts stops polling with ErrPollAttemptsExceeded after n calls to fn. Values below 1 mean no cap.
+func WithMaxAttempts(n int) PollOption {
+	return func(c *pollConfig) {
+		c.maxAttempts = n
+	}
+}
+
+// WithBackoff multiplies the wait by factor after each attempt, never exceeding maxInterval when it is positive.
+// Factors of 1 or less keep the interval constant.
+func WithBackoff(factor float64, maxInterval time.Duration) PollOption {
+	return func(c *pollConfig) {
+		c.factor = factor
+		c.maxInterval = maxInterval
+	}
+}
+
+// WithJitter randomizes each wait by up to ±fraction of its length (e.g. 0.2 for ±20%),
+// so many pollers started together do not hit the backend in lockstep.
+func WithJitter(fraction float64) PollOption {
+	return func(c *pollConfig) {
+		c.jitter = fraction
+	}
+}
+
+// Poll calls fn immediately and then after every interval until it reports done or returns an error,
+// which is passed through unchanged. It returns ctx.Err() as soon as ctx is done, even mid-wait;
+// fn receives ctx and should honor it too.
+func Poll(ctx context.Context, interval time.Duration, fn func(context.Context) (done bool, err error), opts ...PollOption) error {
+	cfg := pollConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	wait := interval
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		done, err := fn(ctx)
+		if err != nil || done {
+			return err
+		}
+		if cfg.maxAttempts > 0 && attempt >= cfg.maxAttempts {
+			return fmt.Errorf("%w: gave up after %d attempts", ErrPollAttemptsExceeded, attempt)
+		}
+
+		timer := time.NewTimer(cfg.jittered(wait))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		wait = cfg.next(wait)
+	}
+}
+
+// next returns the wait that follows current under the configured backoff.
+func (c pollConfig) next(current time.Duration) time.Duration {
+	if c.factor <= 1 {
+		return current
+	}
+
+	next := time.Duration(float64(current) * c.factor)
+	if c.maxInterval > 0 && next > c.maxInterval {
+		return c.maxInterval
+	}
+	return next
+}
+
+// jittered spreads wait randomly by the configured jitter fraction.
+func (c pollConfig) jittered(wait time.Duration) time.Duration {
+	if c.jitter <= 0 || wait <= 0 {
+		return wait
+	}
+
+	spread := float64(wait) * c.jitter
+	return wait + time.Duration((rand.Float64()*2-1)*spread)
+}
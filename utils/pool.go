@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPoolClosed is returned when submitting to a worker pool that has been shut down.
+var ErrPoolClosed = errors.New("worker pool is shut down")
+
+// WorkerPool runs submitted tasks on a fixed number of goroutines.
+type WorkerPool struct {
+	tasks   chan func()
+	workers sync.WaitGroup
+
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewWorkerPool starts a pool that runs at most workers tasks at a time. Values below 1 are treated as 1.
+func NewWorkerPool(workers int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	p := &WorkerPool{tasks: make(chan func()), closing: make(chan struct{})}
+	p.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.run()
+	}
+	return p
+}
+
+// Submit hands task to the next free worker, blocking until one accepts it or the pool is shut down.
+func (p *WorkerPool) Submit(task func()) error {
+	select {
+	case <-p.closing:
+		return ErrPoolClosed
+	default:
+	}
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-p.closing:
+		return ErrPoolClosed
+	}
+}
+
+// SubmitWait submits task and blocks until it has finished running.
+func (p *WorkerPool) SubmitWait(task func()) error {
+	done := make(chan struct{})
+	err := p.Submit(func() {
+		defer close(done)
+		task()
+	})
+	if err != nil {
+		return err
+	}
+
+	<-done
+	return nil
+}
+
+// Shutdown stops accepting tasks, failing pending Submit calls with ErrPoolClosed, and waits for running tasks to finish.
+// It returns the context's error if ctx is done before the workers drain.
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+	})
+
+	drained := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run executes tasks until the pool is shut down.
+func (p *WorkerPool) run() {
+	defer p.workers.Done()
+
+	for {
+		select {
+		case task := <-p.tasks:
+			p.execute(task)
+		case <-p.closing:
+			return
+		}
+	}
+}
+
+// execute runs a single task, logging a panic instead of crashing the process.
+func (p *WorkerPool) execute(task func()) {
+	err := Safe(func() error {
+		task()
+		return nil
+	})
+	logPanic("worker_pool_panic", err)
+}
+
+// ParallelMap applies fn to every element of in using the pool and returns the results in input order.
+// An element whose fn panics leaves the zero value in its slot.
+func ParallelMap[T, U any](p *WorkerPool, in []T, fn func(T) U) ([]U, error) {
+	out := make([]U, len(in))
+
+	var wg sync.WaitGroup
+	for i, item := range in {
+		wg.Add(1)
+		err := p.Submit(func() {
+			defer wg.Done()
+			out[i] = fn(item)
+		})
+		if err != nil {
+			wg.Done()
+			wg.Wait()
+			return nil, err
+		}
+	}
+
+	wg.Wait()
+	return out, nil
+}
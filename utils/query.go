@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// QueryError reports a query parameter that could not be converted to its field's type.
+type QueryError struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("invalid value %q for query parameter %q: %v", e.Value, e.Param, e.Err)
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// DecodeQuery maps the request's query parameters onto the struct pointed to by dst using `query` tags.
+// Supported field types are strings, bools, ints, uints, floats, time.Time (RFC 3339), time.Duration
+// and slices of these, which collect repeated parameters. Fields without a tag are ignored.
+func DecodeQuery(r *http.Request, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("DecodeQuery: dst must be a non-nil pointer to a struct")
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+	query := r.URL.Query()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name := field.Tag.Get("query")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		values, ok := query[name]
+		if !ok || len(values) == 0 {
+			continue
+		}
+
+		target := rv.Field(i)
+		if target.Kind() == reflect.Slice {
+			slice := reflect.MakeSlice(target.Type(), len(values), len(values))
+			for j, raw := range values {
+				if err := setQueryValue(slice.Index(j), raw); err != nil {
+					return &QueryError{Param: name, Value: raw, Err: err}
+				}
+			}
+			target.Set(slice)
+			continue
+		}
+
+		if err := setQueryValue(target, values[0]); err != nil {
+			return &QueryError{Param: name, Value: values[0], Err: err}
+		}
+	}
+	return nil
+}
+
+// setQueryValue converts raw into v's type and stores it.
+func setQueryValue(v reflect.Value, raw string) error {
+	switch v.Type() {
+	case timeType:
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
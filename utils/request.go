@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+)
+
+// IsSecureRequest reports whether the request arrived over HTTPS.
+// When trustForwardedProto is true the X-Forwarded-Proto header set by a TLS-terminating proxy is honored;
+// only enable it behind a proxy that overwrites the header, otherwise clients can spoof it.
+func IsSecureRequest(r *http.Request, trustForwardedProto bool) bool {
+	if r.TLS != nil {
+		return true
+	}
+	if !trustForwardedProto {
+		return false
+	}
+
+	proto := r.Header.Get("X-Forwarded-Proto")
+	if i := strings.IndexByte(proto, ','); i >= 0 {
+		proto = proto[:i]
+	}
+	return strings.EqualFold(strings.TrimSpace(proto), "https")
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+// PanicError wraps a value recovered from a panic together with the stack at the point of the panic.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the recovered value if it was an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Safe runs fn and converts a panic into a returned *PanicError.
+func Safe(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return fn()
+}
+
+// SafeGo runs fn on a new goroutine. A panic is recovered and logged with its stack trace instead of crashing the process.
+func SafeGo(fn func()) {
+	go func() {
+		err := Safe(func() error {
+			fn()
+			return nil
+		})
+		logPanic("goroutine_panic", err)
+	}()
+}
+
+// logPanic logs err through logutil when it is a recovered panic.
+func logPanic(event string, err error) {
+	panicErr, ok := err.(*PanicError)
+	if !ok {
+		return
+	}
+
+	logutil.LogError("", event, panicErr, map[string]interface{}{
+		"stack": string(panicErr.Stack),
+	})
+}
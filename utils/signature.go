@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSignature is returned when a signature header is malformed or no signature matches the payload.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrSignatureExpired is returned when a signature's timestamp is outside the allowed tolerance.
+	ErrSignatureExpired = errors.New("signature timestamp outside tolerance")
+)
+
+// SignPayload signs body for a webhook and returns the header value "t=<unix seconds>,v1=<hex HMAC-SHA256>".
+// The MAC covers "<timestamp>.<body>", so the timestamp cannot be changed without invalidating it.
+func SignPayload(secret, body []byte, timestamp time.Time) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",v1=" + hex.EncodeToString(payloadMAC(secret, t, body))
+}
+
+// VerifySignature checks a header produced by SignPayload against body. Several v1 entries are allowed,
+// e.g. while rotating secrets, and any one matching is enough. Signatures older or newer than tolerance
+// are rejected with ErrSignatureExpired to prevent replays; a tolerance of zero or less disables the check.
+func VerifySignature(secret, body []byte, header string, tolerance time.Duration) error {
+	var timestamp string
+	var signatures [][]byte
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			if mac, err := hex.DecodeString(value); err == nil {
+				signatures = append(signatures, mac)
+			}
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return fmt.Errorf("%w: missing timestamp or v1 signature", ErrInvalidSignature)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+
+	expected := payloadMAC(secret, timestamp, body)
+	matched := false
+	for _, mac := range signatures {
+		if hmac.Equal(mac, expected) {
+			matched = true
+		}
+	}
+	if !matched {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		age := time.Since(time.Unix(seconds, 0))
+		if age > tolerance || age < -tolerance {
+			return fmt.Errorf("%w: signed %s ago", ErrSignatureExpired, age.Round(time.Second))
+		}
+	}
+	return nil
+}
+
+// payloadMAC computes the HMAC-SHA256 of "<timestamp>.<body>".
+func payloadMAC(secret []byte, timestamp string, body []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(timestamp))
+	h.Write([]byte{'.'})
+	h.Write(body)
+	return h.Sum(nil)
+}
@@ -0,0 +1,20 @@
+package utils
+
+// Chunk splits in into consecutive sub-slices of at most size elements; the last one may be shorter.
+// The chunks share in's backing array but are capped, so appending to one never overwrites the next.
+// It returns nil for empty input and panics if size is not positive.
+func Chunk[T any](in []T, size int) [][]T {
+	if size <= 0 {
+		panic("utils.Chunk: size must be greater than zero")
+	}
+	if len(in) == 0 {
+		return nil
+	}
+
+	chunks := make([][]T, 0, (len(in)+size-1)/size)
+	for start := 0; start < len(in); start += size {
+		end := min(start+size, len(in))
+		chunks = append(chunks, in[start:end:end])
+	}
+	return chunks
+}
@@ -0,0 +1,83 @@
+package utils
+
+import "sync"
+
+// SyncMap is a type-safe map guarded by a RWMutex, suited to read-heavy caches.
+// The zero value is ready to use; a SyncMap must not be copied after first use.
+type SyncMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// Load returns the value stored under key and whether it was present.
+func (s *SyncMap[K, V]) Load(key K) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.m[key]
+	return value, ok
+}
+
+// Store sets the value for key.
+func (s *SyncMap[K, V]) Store(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.m == nil {
+		s.m = make(map[K]V)
+	}
+	s.m[key] = value
+}
+
+// LoadOrStore returns the existing value for key if present; otherwise it stores and returns value.
+// loaded reports whether the value was already present.
+func (s *SyncMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	if existing, ok := s.Load(key); ok {
+		return existing, true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.m[key]; ok {
+		return existing, true
+	}
+	if s.m == nil {
+		s.m = make(map[K]V)
+	}
+	s.m[key] = value
+	return value, false
+}
+
+// Delete removes key from the map.
+func (s *SyncMap[K, V]) Delete(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.m, key)
+}
+
+// Range calls fn for each entry until fn returns false. It iterates over a snapshot,
+// so fn may safely call other SyncMap methods.
+func (s *SyncMap[K, V]) Range(fn func(key K, value V) bool) {
+	s.mu.RLock()
+	snapshot := make(map[K]V, len(s.m))
+	for k, v := range s.m {
+		snapshot[k] = v
+	}
+	s.mu.RUnlock()
+
+	for k, v := range snapshot {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// Len returns the number of entries in the map.
+func (s *SyncMap[K, V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.m)
+}
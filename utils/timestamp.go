@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// timestampLayout holds the layout used by FormatTimestamp.
+var timestampLayout atomic.Value
+
+func init() {
+	timestampLayout.Store(time.RFC3339)
+}
+
+// SetTimestampLayout changes the layout FormatTimestamp uses; the default is time.RFC3339.
+func SetTimestampLayout(layout string) {
+	timestampLayout.Store(layout)
+}
+
+// NowUTC returns the current time in UTC.
+func NowUTC() time.Time {
+	return time.Now().UTC()
+}
+
+// FormatTimestamp formats t in UTC with the configured layout, so every service renders times identically.
+func FormatTimestamp(t time.Time) string {
+	return t.UTC().Format(timestampLayout.Load().(string))
+}
+
+// ParseTimestamp parses an RFC 3339 timestamp or a Unix epoch in seconds (or milliseconds, for values
+// with more than 11 digits) and returns it in UTC.
+func ParseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if epoch, err := strconv.ParseInt(s, 10, 64); err == nil {
+		digits := len(strings.TrimPrefix(s, "-"))
+		if digits > 11 {
+			return time.UnixMilli(epoch).UTC(), nil
+		}
+		return time.Unix(epoch, 0).UTC(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timestamp %q is neither RFC 3339 nor a Unix epoch", s)
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Ehsan-Eghbali/common/logutil"
+)
+
+// Tx is the commit/rollback surface WithTx needs; *sql.Tx satisfies it.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// WithTx begins a transaction, runs fn in it and commits when fn succeeds. It rolls back when fn returns
+// an error or panics, re-panicking after the rollback, so a transaction is never left open.
+// Each outcome is logged with the correlation ID from ctx.
+func WithTx(ctx context.Context, beginFn func() (Tx, error), fn func(Tx) error) error {
+	correlationID := logutil.CorrelationIDFromContext(ctx)
+
+	tx, err := beginFn()
+	if err != nil {
+		return logutil.LogAndReturnf(correlationID, "tx_begin", err, nil, "begin transaction")
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			rollbackErr := tx.Rollback()
+			logutil.LogError(correlationID, "tx_rollback", fmt.Errorf("panic: %v", r), map[string]interface{}{
+				"rollbackError": errString(rollbackErr),
+			})
+			panic(r)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = errors.Join(err, fmt.Errorf("rollback: %w", rollbackErr))
+		}
+		return logutil.LogAndReturn(correlationID, "tx_rollback", err, nil)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return logutil.LogAndReturnf(correlationID, "tx_commit", err, nil, "commit transaction")
+	}
+
+	logutil.LogRelationalEnd(correlationID, "tx_commit", nil)
+	return nil
+}
+
+// errString returns err's message, or an empty string for nil.
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}